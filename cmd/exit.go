@@ -0,0 +1,76 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dyegoe/awss/search"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// exitPartialFailure is the exit code when some searches failed, with --fail-on-error.
+	exitPartialFailure = 2
+	// exitTotalFailure is the exit code when all the searches failed.
+	exitTotalFailure = 3
+	// exitNoResults is the exit code when no rows were found, with --fail-on-empty.
+	exitNoResults = 4
+)
+
+// exitError is an error returned by a command which exits with its code, see Execute.
+type exitError struct {
+	code int
+	msg  string
+}
+
+// Error returns the message of the error.
+func (e *exitError) Error() string { return e.msg }
+
+// checkSummary returns an exitError if the run fails, according to the summary of the searches and the flags.
+//
+// All the searches failing is always a failure. Some of them failing is a failure with --fail-on-error,
+// and finding no rows is a failure with --fail-on-empty.
+// When the run fails, the error summary is printed to the stderr of the command, instead of the Cobra error.
+func checkSummary(cmd *cobra.Command, summary search.Summary) error {
+	var err *exitError
+	switch {
+	case summary.AllFailed():
+		err = &exitError{exitTotalFailure, fmt.Sprintf("%d of %d searches failed", summary.Failed, summary.Searches)}
+	case summary.Failed > 0 && viper.GetBool(labelFailOnError):
+		err = &exitError{exitPartialFailure, fmt.Sprintf("%d of %d searches failed", summary.Failed, summary.Searches)}
+	case summary.Rows == 0 && viper.GetBool(labelFailOnEmpty):
+		err = &exitError{exitNoResults, "no results found"}
+	default:
+		return nil
+	}
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	w := cmd.ErrOrStderr()
+	fmt.Fprintf(w, "Error summary: %s\n", err.msg)
+	for _, e := range summary.Errors {
+		fmt.Fprintf(w, "  %s\n", e)
+	}
+	return err
+}
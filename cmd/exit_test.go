@@ -0,0 +1,98 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/dyegoe/awss/search"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Test_checkSummary tests the checkSummary function.
+func Test_checkSummary(t *testing.T) {
+	defer func() {
+		viper.Set(labelFailOnError, nil)
+		viper.Set(labelFailOnEmpty, nil)
+	}()
+
+	partial := search.Summary{Searches: 2, Failed: 1, Rows: 1, Errors: []string{"[p] [r2] denied"}}
+
+	tests := []struct {
+		name        string
+		summary     search.Summary
+		failOnError bool
+		failOnEmpty bool
+		wantCode    int
+		wantStderr  string
+	}{
+		{name: "success", summary: search.Summary{Searches: 1, Rows: 1}},
+		{name: "partial failure", summary: partial},
+		{
+			name:        "partial failure with --fail-on-error",
+			summary:     partial,
+			failOnError: true,
+			wantCode:    exitPartialFailure,
+			wantStderr:  "Error summary: 1 of 2 searches failed\n  [p] [r2] denied\n",
+		},
+		{
+			name:       "total failure",
+			summary:    search.Summary{Searches: 1, Failed: 1, Errors: []string{"[p] [r1] denied"}},
+			wantCode:   exitTotalFailure,
+			wantStderr: "Error summary: 1 of 1 searches failed\n  [p] [r1] denied\n",
+		},
+		{name: "no results", summary: search.Summary{Searches: 1}},
+		{
+			name:        "no results with --fail-on-empty",
+			summary:     search.Summary{Searches: 1},
+			failOnEmpty: true,
+			wantCode:    exitNoResults,
+			wantStderr:  "Error summary: no results found\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(labelFailOnError, tt.failOnError)
+			viper.Set(labelFailOnEmpty, tt.failOnEmpty)
+
+			stderr := bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetErr(&stderr)
+
+			err := checkSummary(cmd, tt.summary)
+
+			code := 0
+			var exitErr *exitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.code
+			}
+			if code != tt.wantCode {
+				t.Errorf("checkSummary() exit code\n%#v\nwant\n%#v", code, tt.wantCode)
+			}
+			if got := stderr.String(); got != tt.wantStderr {
+				t.Errorf("checkSummary() stderr\n%#v\nwant\n%#v", got, tt.wantStderr)
+			}
+		})
+	}
+}
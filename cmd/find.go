@@ -0,0 +1,119 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dyegoe/awss/search"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// findCmd represents the find command.
+//
+// The Long description is built from the search registry, see findLong.
+var findCmd = &cobra.Command{
+	Use:   "find <id>",
+	Short: "Find a resource by its ID.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  findRunE,
+}
+
+// findLongFormat is the Long description of the find command. The placeholder is replaced by the ID prefixes.
+const findLongFormat = `
+Find a resource by its ID.
+The resource type is inferred from the ID prefix:
+  %s.
+The searches run across all the given profiles and regions and stop as soon as the resource is found.
+
+Example:
+	awss --profiles all --regions all find i-1230456078901
+`
+
+// findLongWidth is the maximum width of the ID prefixes lines of the find command description.
+const findLongWidth = 100
+
+// findLong returns the Long description of the find command with the ID prefixes of the given resources,
+// e.g. `ami- (ami), vol- (ebs) and i- (ec2)`.
+func findLong(resources []search.Resource) string {
+	prefixes := []string{}
+	for _, resource := range resources { //nolint:gocritic
+		for _, prefix := range resource.IDPrefixes {
+			prefixes = append(prefixes, fmt.Sprintf("%s (%s)", prefix, resource.Name))
+		}
+	}
+	if len(prefixes) == 0 {
+		return fmt.Sprintf(findLongFormat, "none")
+	}
+
+	last := len(prefixes) - 1
+	for i := 0; i < last-1; i++ {
+		prefixes[i] += ","
+	}
+	if last > 0 {
+		prefixes[last] = "and " + prefixes[last]
+	}
+	return fmt.Sprintf(findLongFormat, wrapWords(prefixes, findLongWidth, "\n  "))
+}
+
+// wrapWords joins the words with spaces, in lines of at most width long, and joins the lines with sep.
+//
+// A word longer than width is kept in its own line.
+func wrapWords(words []string, width int, sep string) string {
+	lines := []string{}
+	current := ""
+	for _, word := range words {
+		if current != "" && len(current)+1+len(word) > width {
+			lines = append(lines, current)
+			current = ""
+		}
+		if current != "" {
+			current += " "
+		}
+		current += word
+	}
+	return strings.Join(append(lines, current), sep)
+}
+
+var _ = registerCommand(findInitFlags, nil)
+
+func findRunE(cmd *cobra.Command, args []string) error {
+	setMetadata(cmd.Name(), nil, map[string][]string{search.FilterID: {args[0]}})
+
+	ctx, cancel := searchContext(cmd)
+	defer cancel()
+
+	return search.Find(
+		ctx,
+		args[0],
+		viper.GetStringSlice(labelProfiles),
+		viper.GetStringSlice(labelRegions),
+		viper.GetString(labelOutput),
+		viper.GetBool(labelShowTags),
+	)
+}
+
+func findInitFlags() {
+	findCmd.Long = findLong(search.Resources())
+	rootCmd.AddCommand(findCmd)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dyegoe/awss/search"
+)
+
+// Test_findLong is a test function for findLong.
+func Test_findLong(t *testing.T) {
+	resources := []search.Resource{
+		{Name: "ami", IDPrefixes: []string{"ami-"}},
+		{Name: "ec2", IDPrefixes: []string{"i-"}},
+		{Name: "ip"},
+		{Name: "vpc", IDPrefixes: []string{"vpc-"}},
+	}
+	want := "The resource type is inferred from the ID prefix:\n  ami- (ami), i- (ec2) and vpc- (vpc).\n"
+	if got := findLong(resources); !strings.Contains(got, want) {
+		t.Errorf("findLong()\n%s\nwant to contain\n%s", got, want)
+	}
+
+	// every registered ID prefix is in the description of the find command
+	got := findLong(search.Resources())
+	for _, resource := range search.Resources() { //nolint:gocritic
+		for _, prefix := range resource.IDPrefixes {
+			if !strings.Contains(got, prefix+" ("+resource.Name+")") {
+				t.Errorf("findLong() missing the %s prefix of %s", prefix, resource.Name)
+			}
+		}
+	}
+}
+
+// Test_wrapWords is a test function for wrapWords.
+func Test_wrapWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  string
+	}{
+		{name: "short line", words: []string{"a", "b"}, want: "a b"},
+		{name: "wrapped line", words: []string{"aaa", "bbb", "ccc"}, want: "aaa bbb|ccc"},
+		{name: "word with spaces", words: []string{"a (a),", "and b (b)"}, want: "a (a),|and b (b)"},
+		{name: "long word", words: []string{"aaaaaaaaaa", "b"}, want: "aaaaaaaaaa|b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapWords(tt.words, 7, "|"); got != tt.want {
+				t.Errorf("wrapWords()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
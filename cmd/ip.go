@@ -0,0 +1,90 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dyegoe/awss/search"
+	searchIP "github.com/dyegoe/awss/search/ip"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	labelIPSort           = "ip.sort"
+	labelIPNoInstanceName = "ip.no-instance-name"
+)
+
+// ipCmd represents the ip command.
+var ipCmd = &cobra.Command{
+	Use:   "ip <address>...",
+	Short: "Look up the owners of IP addresses.",
+	Long: `
+Look up the owners of IP addresses.
+It takes private or public IPv4 addresses, IPv6 addresses, or CIDRs, and searches every profile and region
+for the resources using them: ENIs, Elastic IPs, NAT gateways and load balancers.
+Each match shows the resource type and ID, the attached instance and the account.
+
+Example:
+	awss --profiles all --regions all ip 10.0.1.23 54.210.12.34 10.20.0.0/24 2600:1f18::/56
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: ipRunE,
+}
+
+var _ = registerCommand(ipInitFlags, ipInitViper)
+
+func ipRunE(cmd *cobra.Command, args []string) error {
+	if err := search.CheckSortField(searchIP.SearchName, viper.GetString(labelIPSort)); err != nil {
+		return err
+	}
+
+	if _, err := searchIP.ParseAddresses(args); err != nil {
+		return err
+	}
+
+	return executeSearch(
+		cmd,
+		searchIP.SearchName,
+		map[string][]string{searchIP.FilterAddress: args},
+		labelIPSort, labelIPNoInstanceName,
+	)
+}
+
+func ipInitFlags() {
+	rootCmd.AddCommand(ipCmd)
+
+	ipCmd.Flags().String("sort", "address",
+		"Sort matches by address, ip, type, resource-id, instance-id, instance-name or account. `address`")
+	ipCmd.Flags().Bool("no-instance-name", false,
+		"Skip the instance name lookup to speed up the IP lookup.")
+}
+
+func ipInitViper() error {
+	if err := viper.BindPFlag(labelIPSort, ipCmd.Flags().Lookup("sort")); err != nil {
+		return fmt.Errorf("error binding flag: %w", err)
+	}
+	if err := viper.BindPFlag(labelIPNoInstanceName, ipCmd.Flags().Lookup("no-instance-name")); err != nil {
+		return fmt.Errorf("error binding flag: %w", err)
+	}
+	return nil
+}
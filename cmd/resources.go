@@ -0,0 +1,147 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/dyegoe/awss/common"
+	"github.com/dyegoe/awss/search"
+
+	// The resource packages register their search and command in the search registry when imported.
+	_ "github.com/dyegoe/awss/search/ami"
+	_ "github.com/dyegoe/awss/search/ebs"
+	_ "github.com/dyegoe/awss/search/ec2"
+	_ "github.com/dyegoe/awss/search/eip"
+	_ "github.com/dyegoe/awss/search/eni"
+	_ "github.com/dyegoe/awss/search/ip"
+	_ "github.com/dyegoe/awss/search/sg"
+	_ "github.com/dyegoe/awss/search/snapshot"
+	_ "github.com/dyegoe/awss/search/subnet"
+	_ "github.com/dyegoe/awss/search/tagged"
+	_ "github.com/dyegoe/awss/search/vpc"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// resourceCmds is a map of the commands built from the search registry.
+//
+// The key is the resource name.
+var resourceCmds = map[string]*cobra.Command{}
+
+// resourceLabel returns the viper key of a resource command flag, e.g. `ec2.sort`.
+func resourceLabel(resource, flag string) string {
+	return fmt.Sprintf("%s.%s", resource, flag)
+}
+
+// resourcesInitFlags builds a command for each registered resource with command metadata.
+//
+// Each command has the resource filter flags, --all, --sort and, when enabled, --no-instance-name.
+func resourcesInitFlags() error {
+	for _, resource := range search.Resources() { //nolint:gocritic
+		if resource.Command == nil {
+			continue
+		}
+		cmd, err := newResourceCmd(resource)
+		if err != nil {
+			return err
+		}
+		rootCmd.AddCommand(cmd)
+		resourceCmds[resource.Name] = cmd
+	}
+	return nil
+}
+
+// newResourceCmd returns the command of the given resource.
+func newResourceCmd(resource search.Resource) (*cobra.Command, error) { //nolint:gocritic
+	meta := resource.Command
+	filterFlags := make([]string, 0, len(meta.Flags))
+
+	cmd := &cobra.Command{
+		Use:   resource.Name,
+		Short: meta.Short,
+		Long:  meta.Long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noInstanceNameLabel := ""
+			if meta.NoInstanceName {
+				noInstanceNameLabel = resourceLabel(resource.Name, "no-instance-name")
+			}
+			return runSearch(
+				cmd, resourceLabel(resource.Name, "all"), resourceLabel(resource.Name, "sort"), noInstanceNameLabel,
+				filterFlags, resource.Filters,
+			)
+		},
+	}
+
+	cmd.Flags().BoolP("all", "a", false,
+		fmt.Sprintf("Search for all %s without any filter. Cannot be combined with other filters.", meta.Noun))
+	for _, flag := range meta.Flags {
+		switch value := flag.Value.(type) {
+		case *[]string:
+			cmd.Flags().StringSliceVarP(value, flag.Name, flag.Shorthand, []string{}, flag.Usage)
+		case *[]int:
+			cmd.Flags().IntSliceVarP(value, flag.Name, flag.Shorthand, []int{}, flag.Usage)
+		case *[]net.IP:
+			cmd.Flags().IPSliceVarP(value, flag.Name, flag.Shorthand, []net.IP{}, flag.Usage)
+		default:
+			return nil, fmt.Errorf("resource %s: unsupported type %T for flag %s", resource.Name, flag.Value, flag.Name)
+		}
+		filterFlags = append(filterFlags, flag.Name)
+	}
+
+	sortFields, err := resource.GetSortFields(meta.DefaultSort)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	sortOptions := make([]string, 0, len(sortFields))
+	for option := range sortFields {
+		sortOptions = append(sortOptions, option)
+	}
+	sort.Strings(sortOptions)
+	last := len(sortOptions) - 1
+	cmd.Flags().String("sort", meta.DefaultSort,
+		fmt.Sprintf("Sort %s by %s or %s. `%s`",
+			meta.Noun, common.StringSliceToString(sortOptions[:last], ", "), sortOptions[last], meta.DefaultSort))
+
+	if meta.NoInstanceName {
+		cmd.Flags().Bool("no-instance-name", false,
+			fmt.Sprintf("Skip the instance name lookup to speed up the %s search.", meta.Noun))
+	}
+
+	return cmd, nil
+}
+
+// resourcesInitViper binds the flags of the resource commands to viper.
+func resourcesInitViper() error {
+	for name, cmd := range resourceCmds {
+		for _, flag := range []string{"all", "sort", "no-instance-name"} {
+			if cmd.Flags().Lookup(flag) == nil {
+				continue
+			}
+			if err := viper.BindPFlag(resourceLabel(name, flag), cmd.Flags().Lookup(flag)); err != nil {
+				return fmt.Errorf("error binding flag: %w", err)
+			}
+		}
+	}
+	return nil
+}
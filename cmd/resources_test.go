@@ -0,0 +1,101 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dyegoe/awss/search"
+)
+
+// Test_newResourceCmd tests that every registered resource builds a valid command.
+//
+// It iterates the search registry, so new resources are covered without changing this test.
+func Test_newResourceCmd(t *testing.T) {
+	for _, resource := range search.Resources() { //nolint:gocritic
+		t.Run(resource.Name, func(t *testing.T) {
+			if len(resource.IDPrefixes) > 0 {
+				if _, err := resource.GetSortFields("id"); err != nil {
+					t.Errorf("resource with id prefixes has no id sort field: %v", err)
+				}
+				if resource.IDFilter == "" {
+					t.Error("resource with id prefixes has no id filter")
+				}
+			}
+			if resource.Command == nil {
+				return
+			}
+
+			cmd, err := newResourceCmd(resource)
+			if err != nil {
+				t.Fatalf("newResourceCmd() unexpected error: %v", err)
+			}
+			if cmd.Use != resource.Name {
+				t.Errorf("newResourceCmd() Use = %q, want %q", cmd.Use, resource.Name)
+			}
+			for _, flag := range []string{"all", "sort"} {
+				if cmd.Flags().Lookup(flag) == nil {
+					t.Errorf("newResourceCmd() missing flag --%s", flag)
+				}
+			}
+			if got := cmd.Flags().Lookup("no-instance-name") != nil; got != resource.Command.NoInstanceName {
+				t.Errorf("newResourceCmd() --no-instance-name = %v, want %v", got, resource.Command.NoInstanceName)
+			}
+			for _, flag := range resource.Command.Flags {
+				if cmd.Flags().Lookup(flag.Name) == nil {
+					t.Errorf("newResourceCmd() missing flag --%s", flag.Name)
+				}
+			}
+		})
+	}
+}
+
+// Test_newResourceCmd_invalid tests newResourceCmd with invalid command metadata.
+func Test_newResourceCmd_invalid(t *testing.T) {
+	sortFields := func(string) (map[string]string, error) { return map[string]string{"id": "ID"}, nil }
+	var unsupported []bool
+
+	tests := []struct {
+		name     string
+		resource search.Resource
+	}{
+		{
+			name: "unsupported flag type",
+			resource: search.Resource{
+				Name: "test", GetSortFields: sortFields,
+				Command: &search.Command{Flags: []search.Flag{{Name: "test", Value: &unsupported}}, DefaultSort: "id"},
+			},
+		},
+		{
+			name: "invalid default sort",
+			resource: search.Resource{
+				Name: "test", GetSortFields: search.Resources()[0].GetSortFields,
+				Command: &search.Command{DefaultSort: "invalid"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newResourceCmd(tt.resource); err == nil {
+				t.Error("newResourceCmd() expected error, got nil")
+			}
+		})
+	}
+}
@@ -20,10 +20,14 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/dyegoe/awss/common"
 	"github.com/dyegoe/awss/search"
@@ -46,10 +50,30 @@ const (
 	labelShowTagsCobra  = "show-tags"
 	labelShowTags       = "show.tags"
 	labelAllRegions     = "all-regions"
+	labelMerge          = "merge"
+	labelTemplate       = "template"
+	labelTemplateFile   = "template-file"
+	labelColumns        = "columns"
+	labelStream         = "stream"
+	labelMaxParallel    = "max-parallel"
+	labelRateLimit      = "rate-limit"
+	labelMaxAttempts    = "max-attempts"
+	labelTimeout        = "timeout"
+	labelRegionTimeout  = "region-timeout"
+	labelFailOnError    = "fail-on-error"
+	labelFailOnEmpty    = "fail-on-empty"
+	labelOnAuthError    = "on-auth-error"
 
 	// defaultRegion is used when no --regions flag, AWS_REGION, or
 	// AWS_DEFAULT_REGION is set.
 	defaultRegion = "us-east-1"
+
+	// defaultMaxParallel is the default maximum number of searches running at the same time.
+	defaultMaxParallel = 20
+	// defaultRateLimit is the default number of EC2 API calls per second of each account and region.
+	defaultRateLimit = 20
+	// defaultMaxAttempts is the default maximum number of attempts of an API call.
+	defaultMaxAttempts = 10
 )
 
 // version is overridden at build time via -ldflags.
@@ -76,35 +100,80 @@ https://github.com/dyegoe/awss`,
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	initFlags()
-	ec2InitFlags()
-	eniInitFlags()
-	ebsInitFlags()
 
-	if err := initViper(); err != nil {
+	if err := resourcesInitFlags(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	if err := ec2InitViper(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	for _, c := range commands {
+		c.initFlags()
 	}
 
-	if err := eniInitViper(); err != nil {
+	if err := initViper(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	if err := ebsInitViper(); err != nil {
+	if err := resourcesInitViper(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	for _, c := range commands {
+		if c.initViper == nil {
+			continue
+		}
+		if err := c.initViper(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	// The searches are cancelled on interrupt, the results completed so far are still printed.
+	// A second interrupt exits immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.code)
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
 
+// command is a hand-written command, with its own flags and RunE.
+//
+// The resource search commands are built from the search registry instead, see resourcesInitFlags.
+type command struct {
+	// initFlags adds the command and its flags.
+	initFlags func()
+
+	// initViper binds the command flags to viper. It can be nil.
+	initViper func() error
+}
+
+// commands lists the hand-written commands.
+//
+// They are initialized by Execute after the resource commands, so they can be added as subcommands of them.
+var commands []command
+
+// registerCommand adds a hand-written command to be initialized by Execute.
+//
+// It is meant to be called from a package-level variable declaration, e.g. `var _ = registerCommand(...)`.
+func registerCommand(initFlags func(), initViper func() error) bool {
+	commands = append(commands, command{initFlags: initFlags, initViper: initViper})
+	return true
+}
+
 // persistentPreRun is executed before any command.
 func persistentPreRun(cmd *cobra.Command, args []string) error {
 	cfg, err := cmd.Flags().GetString(labelConfig)
@@ -134,9 +203,141 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 			viper.GetString(labelOutput), validList)
 	}
 
+	output, err := checkMerge(viper.GetBool(labelMerge), viper.GetString(labelOutput))
+	if err != nil {
+		return err
+	}
+	viper.Set(labelOutput, output)
+
+	err = setLimits(viper.GetInt(labelMaxParallel), viper.GetInt(labelRateLimit), viper.GetInt(labelMaxAttempts))
+	if err != nil {
+		return err
+	}
+
+	if err := checkTimeouts(viper.GetDuration(labelTimeout), viper.GetDuration(labelRegionTimeout)); err != nil {
+		return err
+	}
+
+	if err := checkOnAuthError(viper.GetString(labelOnAuthError)); err != nil {
+		return err
+	}
+
+	return checkTemplate(output, viper.GetString(labelTemplate), viper.GetString(labelTemplateFile))
+}
+
+// checkTimeouts sets the timeout of the search of each profile and region.
+//
+// The overall timeout is applied to the context of the searches, see searchContext.
+// It returns an error if one of the timeouts is negative.
+func checkTimeouts(timeout, regionTimeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("invalid %s: %s. It must be 0 or greater", labelTimeout, timeout)
+	}
+	if regionTimeout < 0 {
+		return fmt.Errorf("invalid %s: %s. It must be 0 or greater", labelRegionTimeout, regionTimeout)
+	}
+
+	search.SetRegionTimeout(regionTimeout)
+
+	return nil
+}
+
+// checkOnAuthError sets the policy for the profiles with invalid credentials.
+//
+// It returns an error if the policy is not skip or fail.
+func checkOnAuthError(policy string) error {
+	if policy != search.AuthErrorSkip && policy != search.AuthErrorFail {
+		return fmt.Errorf("invalid %s: %s. It must be %s or %s",
+			labelOnAuthError, policy, search.AuthErrorSkip, search.AuthErrorFail)
+	}
+
+	search.SetOnAuthError(policy)
+
+	return nil
+}
+
+// searchContext returns the context of the searches of the command, with the --timeout if any.
+//
+// The context of the command is cancelled on interrupt, see Execute.
+func searchContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if timeout := viper.GetDuration(labelTimeout); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// setLimits sets the maximum number of parallel searches and the limits of the AWS API calls.
+//
+// It returns an error if one of the limits is negative.
+func setLimits(maxParallel, rateLimit, maxAttempts int) error {
+	limits := []struct {
+		label string
+		value int
+	}{
+		{labelMaxParallel, maxParallel},
+		{labelRateLimit, rateLimit},
+		{labelMaxAttempts, maxAttempts},
+	}
+	for _, l := range limits {
+		if l.value < 0 {
+			return fmt.Errorf("invalid %s: %d. It must be 0 or greater", l.label, l.value)
+		}
+	}
+
+	search.SetMaxParallel(maxParallel)
+	common.SetLimits(common.Limits{RateLimit: rateLimit, MaxAttempts: maxAttempts})
+
 	return nil
 }
 
+// checkTemplate sets the template of the template output, from --template or --template-file.
+//
+// It returns an error if the template output has no template, or both,
+// if the template flags are used with another output format, or if the template is invalid.
+func checkTemplate(output, text, file string) error {
+	if output != common.Template {
+		if text != "" || file != "" {
+			return fmt.Errorf("--%s and --%s can only be used with the %s output",
+				labelTemplate, labelTemplateFile, common.Template)
+		}
+		return nil
+	}
+
+	switch {
+	case text != "" && file != "":
+		return fmt.Errorf("use either --%s or --%s, not both", labelTemplate, labelTemplateFile)
+	case text == "" && file == "":
+		return fmt.Errorf("the %s output needs --%s or --%s", common.Template, labelTemplate, labelTemplateFile)
+	case file != "":
+		b, err := os.ReadFile(file) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("reading template file: %w", err)
+		}
+		text = string(b)
+	}
+
+	return common.SetTemplate(text)
+}
+
+// checkMerge returns the output format to use with the --merge flag.
+//
+// With --merge, the table output is replaced by the table-merged output.
+// It returns an error if --merge is used with another output format.
+func checkMerge(merge bool, output string) (string, error) {
+	if !merge || output == common.TableMerged {
+		return output, nil
+	}
+	if output != common.Table {
+		return "", fmt.Errorf("--merge can only be used with the %s output, got: %s", common.Table, output)
+	}
+	return common.TableMerged, nil
+}
+
 // initFlags initializes cobras flags.
 func initFlags() {
 	validOutputs, _ := common.ValidOutputs("")
@@ -155,6 +356,39 @@ func initFlags() {
 		))
 	rootCmd.PersistentFlags().String(labelOutput, "table",
 		fmt.Sprintf("Select the output format. Valid outputs are: %s", validOutputs))
+	rootCmd.PersistentFlags().Bool(labelMerge, false,
+		"Merge the results of all profiles and regions in one table. Same as --output table-merged.")
+	rootCmd.PersistentFlags().String(labelTemplate, "",
+		"Go template executed for each row with the template output. `'{{.InstanceID}} {{.PrivateIPAddress}}'`")
+	rootCmd.PersistentFlags().String(labelTemplateFile, "",
+		"File with the Go template executed for each row with the template output. `instances.tmpl`")
+	rootCmd.PersistentFlags().StringSlice(labelColumns, []string{},
+		"Select and order the columns of the table and csv outputs. Defaults to <command>.columns in the config file. "+
+			"`id,name,private-ip,tag:Owner`")
+	rootCmd.PersistentFlags().Bool(labelStream, false,
+		"Print the results of each profile and region as soon as they are ready, instead of in the order of "+
+			"the profiles and regions. Default for the ndjson and yaml-stream outputs.")
+	rootCmd.PersistentFlags().Int(labelMaxParallel, defaultMaxParallel,
+		"Maximum number of profile and region searches running at the same time. 0 means no limit.")
+	rootCmd.PersistentFlags().Int(labelRateLimit, defaultRateLimit,
+		"Maximum number of EC2 API calls per second for each account and region. 0 means no limit.")
+	rootCmd.PersistentFlags().Int(labelMaxAttempts, defaultMaxAttempts,
+		"Maximum number of attempts of a throttled or failed API call, retried with the adaptive retry mode.")
+	rootCmd.PersistentFlags().Duration(labelTimeout, 0,
+		"Stop the searches after the timeout and print the results completed so far. 0 means no timeout. `5m`")
+	rootCmd.PersistentFlags().Duration(labelRegionTimeout, 0,
+		"Stop the search of a profile and region after the timeout. 0 means no timeout. `30s`")
+	rootCmd.PersistentFlags().Bool(labelFailOnError, false,
+		fmt.Sprintf("Exit with code %d if some searches failed. It always exits with code %d if all of them failed.",
+			exitPartialFailure, exitTotalFailure))
+	rootCmd.PersistentFlags().Bool(labelFailOnEmpty, false,
+		fmt.Sprintf("Exit with code %d if no results were found.", exitNoResults))
+	rootCmd.PersistentFlags().String(labelOnAuthError, search.AuthErrorFail,
+		fmt.Sprintf("What to do when the credentials of a profile are invalid: %s stops before searching, "+
+			"%s searches the other profiles. The credentials are checked in the region of the profile, "+
+			"or %s if it has none. `%s|%s`",
+			search.AuthErrorFail, search.AuthErrorSkip, common.DefaultIdentityRegion, search.AuthErrorSkip,
+			search.AuthErrorFail))
 	rootCmd.PersistentFlags().Bool(labelShowEmptyCobra, false,
 		"Show empty resources. Default is false.")
 	rootCmd.PersistentFlags().Bool(labelShowTagsCobra, false,
@@ -192,6 +426,17 @@ func initViper() error {
 	if err := viper.BindPFlag(labelOutput, rootCmd.PersistentFlags().Lookup(labelOutput)); err != nil {
 		return fmt.Errorf("error binding flag %s: %w", labelOutput, err)
 	}
+	if err := viper.BindPFlag(labelMerge, rootCmd.PersistentFlags().Lookup(labelMerge)); err != nil {
+		return fmt.Errorf("error binding flag %s: %w", labelMerge, err)
+	}
+	for _, label := range []string{
+		labelTemplate, labelTemplateFile, labelColumns, labelStream, labelMaxParallel, labelRateLimit, labelMaxAttempts,
+		labelTimeout, labelRegionTimeout, labelFailOnError, labelFailOnEmpty, labelOnAuthError,
+	} {
+		if err := viper.BindPFlag(label, rootCmd.PersistentFlags().Lookup(label)); err != nil {
+			return fmt.Errorf("error binding flag %s: %w", label, err)
+		}
+	}
 	if err := viper.BindPFlag(labelShowEmpty, rootCmd.PersistentFlags().Lookup(labelShowEmptyCobra)); err != nil {
 		return fmt.Errorf("error binding flag %s: %w", labelShowEmpty, err)
 	}
@@ -343,12 +588,11 @@ func checkAvailabilityZones(az []string) error {
 // buildFilters validates and builds the filter map for a subcommand.
 //
 // When allFlag is true, it checks that no filter flags were set and returns an empty map.
-// Otherwise, it validates availability zones and tags, then converts the filter struct.
+// Otherwise, it converts the filter struct, then validates the availability zones and tags.
 func buildFilters(
 	cmd *cobra.Command,
 	allFlag bool,
 	filterFlags []string,
-	azs, tags []string,
 	filterStruct interface{},
 ) (map[string][]string, error) {
 	if allFlag {
@@ -360,41 +604,62 @@ func buildFilters(
 		return map[string][]string{}, nil
 	}
 
-	if err := checkAvailabilityZones(azs); err != nil && !errors.Is(err, errNoAZSelected) {
+	filters, err := common.StructToFilters(filterStruct)
+	if err != nil {
 		return nil, err
 	}
 
-	if _, err := common.ParseTags(tags); err != nil {
+	if err := checkAvailabilityZones(filters["availability-zone"]); err != nil && !errors.Is(err, errNoAZSelected) {
 		return nil, err
 	}
 
-	return common.StructToFilters(filterStruct)
+	if _, err := common.ParseTags(filters["tag"]); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
 }
 
-// runSearch is the common RunE body for ec2, eni, and ebs commands.
+// runSearch is the common RunE body for the resource search commands.
 //
 // It validates the sort field, builds filters, and executes the search.
 func runSearch(
 	cmd *cobra.Command,
 	allLabel, sortLabel, noInstanceNameLabel string,
 	filterFlags []string,
-	azs, tags []string,
 	filterStruct interface{},
 ) error {
 	if err := search.CheckSortField(cmd.Name(), viper.GetString(sortLabel)); err != nil {
 		return err
 	}
 
-	filters, err := buildFilters(
-		cmd, viper.GetBool(allLabel), filterFlags,
-		azs, tags, filterStruct,
-	)
+	filters, err := buildFilters(cmd, viper.GetBool(allLabel), filterFlags, filterStruct)
 	if err != nil {
 		return err
 	}
 
-	return search.Execute(
-		cmd.Name(),
+	return executeSearch(cmd, cmd.Name(), filters, sortLabel, noInstanceNameLabel)
+}
+
+// executeSearch runs search.Execute for the given search name with the global flags and the context of the command.
+//
+// The columns are set for the results without type, see setColumns, and the metadata, see setMetadata.
+// The sort field and the no-instance-name flag are read from viper using the given labels.
+// It returns an exitError if the run fails, see checkSummary.
+func executeSearch(
+	cmd *cobra.Command, name string, filters map[string][]string, sortLabel, noInstanceNameLabel string,
+) error {
+	if err := setColumns("", name); err != nil {
+		return err
+	}
+	setMetadata(name, nil, filters)
+
+	ctx, cancel := searchContext(cmd)
+	defer cancel()
+
+	summary, err := search.Execute(
+		ctx,
+		name,
 		viper.GetStringSlice(labelProfiles),
 		viper.GetStringSlice(labelRegions),
 		filters,
@@ -403,5 +668,51 @@ func runSearch(
 		viper.GetBool(labelShowEmpty),
 		viper.GetBool(labelShowTags),
 		noInstanceNameLabel != "" && viper.GetBool(noInstanceNameLabel),
+		streamResults(),
 	)
+	if err != nil {
+		return err
+	}
+
+	return checkSummary(cmd, summary)
+}
+
+// streamResults returns if the results are printed as soon as each search is done,
+// with --stream or with an output streaming by default, see common.StreamingOutput.
+func streamResults() bool {
+	return viper.GetBool(labelStream) || common.StreamingOutput(viper.GetString(labelOutput))
+}
+
+// setColumns selects the columns of the results of the given type, from --columns or <name>.columns in the config.
+//
+// The type is empty for the single resource searches, see common.SetColumns.
+// It returns an error if one of the columns is not valid for the search name.
+func setColumns(resourceType, name string) error {
+	columns := viper.GetStringSlice(labelColumns)
+	if len(columns) == 0 {
+		columns = viper.GetStringSlice(resourceLabel(name, labelColumns))
+	}
+
+	if len(columns) > 0 {
+		if err := search.CheckColumns(name, columns); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	common.SetColumns(resourceType, columns)
+
+	return nil
+}
+
+// setMetadata sets the metadata of the search shown in the json-document output.
+//
+// The version, the profiles and the regions are read from the global flags.
+func setMetadata(command string, types []string, filters map[string][]string) {
+	common.SetMetadata(common.Metadata{
+		Version:  version,
+		Command:  command,
+		Types:    types,
+		Filters:  filters,
+		Profiles: viper.GetStringSlice(labelProfiles),
+		Regions:  viper.GetStringSlice(labelRegions),
+	})
 }
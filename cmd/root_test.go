@@ -20,8 +20,16 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/dyegoe/awss/common"
+	"github.com/dyegoe/awss/search"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Test_initConfig tests the initConfig function.
@@ -227,3 +235,232 @@ func Test_checkAvailabilityZones(t *testing.T) {
 		})
 	}
 }
+
+// Test_checkMerge tests the checkMerge function.
+func Test_checkMerge(t *testing.T) {
+	type args struct {
+		merge  bool
+		output string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{name: "no merge", args: args{merge: false, output: "json"}, want: "json"},
+		{name: "merge table", args: args{merge: true, output: "table"}, want: "table-merged"},
+		{name: "merge table-merged", args: args{merge: true, output: "table-merged"}, want: "table-merged"},
+		{name: "merge json", args: args{merge: true, output: "json"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkMerge(tt.args.merge, tt.args.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkMerge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("checkMerge()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_checkTemplate tests the checkTemplate function.
+func Test_checkTemplate(t *testing.T) {
+	type args struct {
+		output string
+		text   string
+		file   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "no template output", args: args{output: "table"}},
+		{name: "template", args: args{output: "template", text: "{{.InstanceID}}"}},
+		{name: "template file", args: args{output: "template", file: "testdata/template.tmpl"}},
+		{name: "missing template file", args: args{output: "template", file: "testdata/missing.tmpl"}, wantErr: true},
+		{name: "invalid template", args: args{output: "template", text: "{{.InstanceID"}, wantErr: true},
+		{name: "no template", args: args{output: "template"}, wantErr: true},
+		{
+			name:    "template and template file",
+			args:    args{output: "template", text: "{{.InstanceID}}", file: "testdata/template.tmpl"},
+			wantErr: true,
+		},
+		{name: "template with another output", args: args{output: "json", text: "{{.InstanceID}}"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkTemplate(tt.args.output, tt.args.text, tt.args.file); (err != nil) != tt.wantErr {
+				t.Errorf("checkTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_setColumns tests the setColumns function.
+func Test_setColumns(t *testing.T) {
+	defer func() {
+		viper.Set(labelColumns, nil)
+		viper.Set(resourceLabel("ec2", labelColumns), nil)
+		common.SetColumns("", nil)
+	}()
+
+	tests := []struct {
+		name    string
+		flag    []string
+		config  []string
+		wantErr bool
+	}{
+		{name: "no columns"},
+		{name: "columns from the config", config: []string{"id", "tag:Owner"}},
+		{name: "columns from the flag", flag: []string{"id", "name"}, config: []string{"invalid"}},
+		{name: "invalid column", flag: []string{"invalid"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(labelColumns, tt.flag)
+			viper.Set(resourceLabel("ec2", labelColumns), tt.config)
+			if err := setColumns("", "ec2"); (err != nil) != tt.wantErr {
+				t.Errorf("setColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_streamResults tests the streamResults function.
+func Test_streamResults(t *testing.T) {
+	defer func() {
+		viper.Set(labelStream, nil)
+		viper.Set(labelOutput, nil)
+	}()
+
+	tests := []struct {
+		name   string
+		stream bool
+		output string
+		want   bool
+	}{
+		{name: "table is ordered", output: "table", want: false},
+		{name: "table with --stream", stream: true, output: "table", want: true},
+		{name: "ndjson streams by default", output: "ndjson", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(labelStream, tt.stream)
+			viper.Set(labelOutput, tt.output)
+			if got := streamResults(); got != tt.want {
+				t.Errorf("streamResults()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_setLimits tests the setLimits function.
+func Test_setLimits(t *testing.T) {
+	defer func() {
+		_ = setLimits(0, 0, 0)
+	}()
+
+	tests := []struct {
+		name        string
+		maxParallel int
+		rateLimit   int
+		maxAttempts int
+		wantErr     bool
+	}{
+		{name: "defaults", maxParallel: defaultMaxParallel, rateLimit: defaultRateLimit, maxAttempts: defaultMaxAttempts},
+		{name: "no limits", maxParallel: 0, rateLimit: 0, maxAttempts: 0},
+		{name: "negative max parallel", maxParallel: -1, wantErr: true},
+		{name: "negative rate limit", rateLimit: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := setLimits(tt.maxParallel, tt.rateLimit, tt.maxAttempts); (err != nil) != tt.wantErr {
+				t.Errorf("setLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_checkTimeouts tests the checkTimeouts function.
+func Test_checkTimeouts(t *testing.T) {
+	defer func() {
+		_ = checkTimeouts(0, 0)
+	}()
+
+	tests := []struct {
+		name          string
+		timeout       time.Duration
+		regionTimeout time.Duration
+		wantErr       bool
+	}{
+		{name: "no timeouts"},
+		{name: "timeouts", timeout: time.Minute, regionTimeout: 10 * time.Second},
+		{name: "negative timeout", timeout: -time.Second, wantErr: true},
+		{name: "negative region timeout", regionTimeout: -time.Second, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkTimeouts(tt.timeout, tt.regionTimeout); (err != nil) != tt.wantErr {
+				t.Errorf("checkTimeouts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_checkOnAuthError tests the checkOnAuthError function.
+func Test_checkOnAuthError(t *testing.T) {
+	defer func() {
+		_ = checkOnAuthError(search.AuthErrorFail)
+	}()
+
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{name: "skip", policy: search.AuthErrorSkip},
+		{name: "fail", policy: search.AuthErrorFail},
+		{name: "invalid policy", policy: "ignore", wantErr: true},
+		{name: "empty policy", policy: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkOnAuthError(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("checkOnAuthError() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_searchContext tests the searchContext function.
+func Test_searchContext(t *testing.T) {
+	defer viper.Set(labelTimeout, nil)
+
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+	}{
+		{name: "no timeout", timeout: 0, wantDeadline: false},
+		{name: "timeout", timeout: time.Minute, wantDeadline: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(labelTimeout, tt.timeout)
+
+			cmd := &cobra.Command{}
+			cmd.SetContext(context.Background())
+			ctx, cancel := searchContext(cmd)
+			defer cancel()
+
+			if _, got := ctx.Deadline(); got != tt.wantDeadline {
+				t.Errorf("searchContext() deadline\n%#v\nwant\n%#v", got, tt.wantDeadline)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dyegoe/awss/common"
+	"github.com/dyegoe/awss/search"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	labelSearchTypes          = "search.types"
+	labelSearchAll            = "search.all"
+	labelSearchNoInstanceName = "search.no-instance-name"
+)
+
+// searchFilters represents the filters shared by the resource types of the search command.
+//
+// The `id` filter is split by ID prefix between the types, see search.PlanSearches.
+type searchFilters struct {
+	Ids               []string `filter:"id"`
+	Tags              []string `filter:"tag"`
+	TagsKey           []string `filter:"tag-key"`
+	AvailabilityZones []string `filter:"availability-zone"`
+}
+
+// searchF holds the values of the search command filter flags.
+var searchF = searchFilters{}
+
+// searchFilterFlags are the names of the search command filter flags.
+var searchFilterFlags = []string{"ids", "tags", "tags-key", "availability-zones"}
+
+// searchCmd represents the search command.
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search for several resource types at once.",
+	Long: `
+Search for several resource types at once, with the same filters.
+You can search using the following filters: ids, tags, tags-key and availability-zones.
+The IDs are passed to the type matching their prefix, e.g. i- to ec2 and vol- to ebs.
+The results are grouped by type. Each type is sorted by its own sort field, e.g. ec2.sort in the config file.
+The csv and tsv outputs print one section per type, with its own header, so they cannot be combined with --stream.
+
+Example:
+	awss search --types ec2,eni,ebs --tags Project=foo
+
+Use --all to search for all resources of the types without any filter.
+This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+	RunE: searchRunE,
+}
+
+var _ = registerCommand(searchInitFlags, searchInitViper)
+
+func searchRunE(cmd *cobra.Command, _ []string) error {
+	types := viper.GetStringSlice(labelSearchTypes)
+	if len(types) == 0 {
+		return fmt.Errorf("you must provide at least one type with --types. The options are: %s",
+			common.StringSliceToString(search.MultiTypes(), ", "))
+	}
+
+	filters, err := buildFilters(cmd, viper.GetBool(labelSearchAll), searchFilterFlags, &searchF)
+	if err != nil {
+		return err
+	}
+
+	searches, err := search.PlanSearches(types, filters)
+	if err != nil {
+		return err
+	}
+	if err := checkStreamTypes(viper.GetString(labelOutput), streamResults(), len(searches)); err != nil {
+		return err
+	}
+	for i := range searches {
+		searches[i].SortField = viper.GetString(resourceLabel(searches[i].Name, "sort"))
+		if err := search.CheckSortField(searches[i].Name, searches[i].SortField); err != nil {
+			return err
+		}
+		if err := setColumns(searches[i].Name, searches[i].Name); err != nil {
+			return err
+		}
+	}
+
+	setMetadata(cmd.Name(), types, filters)
+
+	ctx, cancel := searchContext(cmd)
+	defer cancel()
+
+	summary, err := search.ExecuteMany(
+		ctx,
+		searches,
+		viper.GetStringSlice(labelProfiles),
+		viper.GetStringSlice(labelRegions),
+		viper.GetString(labelOutput),
+		viper.GetBool(labelShowEmpty),
+		viper.GetBool(labelShowTags),
+		viper.GetBool(labelSearchNoInstanceName),
+		streamResults(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkSummary(cmd, summary)
+}
+
+// checkStreamTypes returns an error if the results of several types are streamed with an output printing
+// a header once, e.g. csv. The types have different columns, so the header would be printed again each time
+// the type of the streamed results changes. Without --stream, the results are grouped by type.
+func checkStreamTypes(output string, stream bool, types int) error {
+	if stream && types > 1 && common.HeaderOutput(output) {
+		return fmt.Errorf("--%s cannot be combined with the %s output when searching several types", labelStream, output)
+	}
+	return nil
+}
+
+func searchInitFlags() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringSliceP("types", "T", []string{},
+		fmt.Sprintf("Resource types to search, among %s. `ec2,eni,ebs`",
+			common.StringSliceToString(search.MultiTypes(), ", ")))
+	searchCmd.Flags().BoolP("all", "a", false,
+		"Search for all resources of the types without any filter. Cannot be combined with other filters.")
+	searchCmd.Flags().StringSliceVarP(&searchF.Ids, "ids", "i", []string{},
+		"Filter resources by IDs. Each ID is passed to the type matching its prefix. `i-1230456078901,vol-1230456078902`")
+	searchCmd.Flags().StringSliceVarP(&searchF.Tags, "tags", "t", []string{},
+		"Filter resources by tags. `'Key=Value1:Value2,Environment=Production'`")
+	searchCmd.Flags().StringSliceVarP(&searchF.TagsKey, "tags-key", "k", []string{},
+		"Filter resources by tags key. `Key,Environment`")
+	searchCmd.Flags().StringSliceVarP(&searchF.AvailabilityZones, "availability-zones", "z", []string{},
+		"Filter resources by availability zones. It will append to current region. `a,b`")
+	searchCmd.Flags().Bool("no-instance-name", false,
+		"Skip the instance name lookup to speed up the search.")
+}
+
+func searchInitViper() error {
+	for label, flag := range map[string]string{
+		labelSearchTypes:          "types",
+		labelSearchAll:            "all",
+		labelSearchNoInstanceName: "no-instance-name",
+	} {
+		if err := viper.BindPFlag(label, searchCmd.Flags().Lookup(flag)); err != nil {
+			return fmt.Errorf("error binding flag: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// Test_checkStreamTypes is a test function for checkStreamTypes.
+func Test_checkStreamTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		stream  bool
+		types   int
+		wantErr bool
+	}{
+		{name: "csv of several types", output: common.CSV, stream: false, types: 2, wantErr: false},
+		{name: "streamed csv of one type", output: common.CSV, stream: true, types: 1, wantErr: false},
+		{name: "streamed csv of several types", output: common.CSV, stream: true, types: 2, wantErr: true},
+		{name: "streamed tsv of several types", output: common.TSV, stream: true, types: 3, wantErr: true},
+		{name: "streamed json of several types", output: common.JSON, stream: true, types: 2, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkStreamTypes(tt.output, tt.stream, tt.types); (err != nil) != tt.wantErr {
+				t.Errorf("checkStreamTypes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd enables the CLI commands and flags.
+//
+// It is based on Cobra and Viper.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dyegoe/awss/common"
+	"github.com/dyegoe/awss/search"
+	searchSG "github.com/dyegoe/awss/search/sg"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	labelSgAuditPorts          = "sg.audit.ports"
+	labelSgAuditSort           = "sg.audit.sort"
+	labelSgAuditNoInstanceName = "sg.audit.no-instance-name"
+)
+
+// sgAuditCmd represents the sg audit command.
+var sgAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit security groups for risky ingress rules.",
+	Long: `
+Audit security groups for risky ingress rules.
+It flags every inbound rule that opens a sensitive port to 0.0.0.0/0 or ::/0.
+Rules allowing all protocols or a port range including a sensitive port are flagged too.
+Each finding shows the ENIs and instances the security group is attached to.
+
+The default sensitive ports are SSH, RDP and common databases:
+  22, 3389, 1433, 1521, 3306, 5432, 5439, 6379, 9042, 9200, 11211 and 27017.
+You can replace them with --ports or with the config key sg.audit.ports.
+
+Example:
+	awss --profiles all --regions all sg audit --ports 22,3389,8080
+`,
+	RunE: sgAuditRunE,
+}
+
+var _ = registerCommand(sgAuditInitFlags, sgAuditInitViper)
+
+func sgAuditRunE(cmd *cobra.Command, args []string) error {
+	if err := search.CheckSortField(searchSG.AuditSearch, viper.GetString(labelSgAuditSort)); err != nil {
+		return err
+	}
+
+	ports := viper.GetIntSlice(labelSgAuditPorts)
+	if len(ports) == 0 {
+		return fmt.Errorf("you must provide at least one port to audit")
+	}
+
+	return executeSearch(
+		cmd,
+		searchSG.AuditSearch,
+		map[string][]string{"port": common.IntToString(ports)},
+		labelSgAuditSort, labelSgAuditNoInstanceName,
+	)
+}
+
+// sgAuditInitFlags adds the audit command to the sg command built from the search registry.
+func sgAuditInitFlags() {
+	resourceCmds["sg"].AddCommand(sgAuditCmd)
+
+	sgAuditCmd.Flags().IntSliceP("ports", "p", searchSG.DefaultAuditPorts,
+		"Sensitive ports to audit. `22,3389`")
+	sgAuditCmd.Flags().String("sort", "group-id",
+		"Sort findings by group-id, group-name, vpc-id, rule or source. `group-id`")
+	sgAuditCmd.Flags().Bool("no-instance-name", false,
+		"Skip the instance name lookup to speed up the audit.")
+}
+
+func sgAuditInitViper() error {
+	if err := viper.BindPFlag(labelSgAuditPorts, sgAuditCmd.Flags().Lookup("ports")); err != nil {
+		return fmt.Errorf("error binding flag: %w", err)
+	}
+	if err := viper.BindPFlag(labelSgAuditSort, sgAuditCmd.Flags().Lookup("sort")); err != nil {
+		return fmt.Errorf("error binding flag: %w", err)
+	}
+	if err := viper.BindPFlag(labelSgAuditNoInstanceName, sgAuditCmd.Flags().Lookup("no-instance-name")); err != nil {
+		return fmt.Errorf("error binding flag: %w", err)
+	}
+	return nil
+}
@@ -24,43 +24,121 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
 	"gopkg.in/ini.v1"
 )
 
 // AwsConfig returns a AWS config for the specific profile and region.
-func AwsConfig(profile, region string) (aws.Config, error) {
-	cfg, err := config.LoadDefaultConfig(
-		context.Background(),
+//
+// The API calls are retried with the adaptive retry mode and the EC2 API calls are rate limited, see SetLimits.
+// The retryer and the rate limit are shared by all the profiles of the same account in the region, see limitsKey.
+func AwsConfig(ctx context.Context, profile, region string) (aws.Config, error) {
+	key := limitsKey(profile, region)
+	optFns := []func(*config.LoadOptions) error{
 		config.WithSharedConfigProfile(profile),
 		config.WithRegion(region),
-	)
+		config.WithRetryer(sharedRetryer(key)),
+	}
+	if limiter := rateLimiter(key); limiter != nil {
+		optFns = append(optFns, config.WithAPIOptions([]func(*middleware.Stack) error{limiter}))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
 	if err != nil {
 		return cfg, err
 	}
 	return cfg, nil
 }
 
+// limitsKey returns the key of the retryer and the token bucket of the profile in the region.
+//
+// The EC2 API limits are per account and region. The account is taken from the identity cached by the
+// credentials check, see CallerIdentity, so no API call is made. A profile without a cached identity
+// is keyed by its name.
+func limitsKey(profile, region string) string {
+	if identity, ok := identities.Load(profile); ok {
+		return identity.(Identity).Account + "/" + region
+	}
+	return "profile:" + profile + "/" + region
+}
+
+// Identity is the AWS identity of the credentials of a profile.
+type Identity struct {
+	// Account is the AWS account ID.
+	Account string
+
+	// ARN is the ARN of the user or the assumed role.
+	ARN string
+}
+
 // WhoAmI returns the AWS account ID and error.
 //
 // The profile and region are used to create the AWS config.
 // The AWS account ID is returned by the STS GetCallerIdentity API.
-// This function is used as workaround to pre-authenticate the AWS config.
-func WhoAmI(profile, region string) (string, error) {
-	cfg, err := AwsConfig(profile, region)
+func WhoAmI(ctx context.Context, profile, region string) (string, error) {
+	identity, err := callerIdentity(ctx, profile, region)
 	if err != nil {
 		return "", err
 	}
+	return identity.Account, nil
+}
+
+// DefaultIdentityRegion is the region of the STS API calls of the profiles without a region, see callerIdentity.
+const DefaultIdentityRegion = "us-east-1"
+
+// callerIdentity returns the AWS identity of the profile and error, from the STS GetCallerIdentity API.
+//
+// If region is empty, the region of the profile is used, or DefaultIdentityRegion if the profile has none.
+func callerIdentity(ctx context.Context, profile, region string) (Identity, error) {
+	cfg, err := AwsConfig(ctx, profile, region)
+	if err != nil {
+		return Identity{}, err
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultIdentityRegion
+	}
 	client := sts.NewFromConfig(cfg)
-	resp, err := client.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+	resp, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return Identity{}, err
+	}
+	return Identity{Account: aws.ToString(resp.Account), ARN: aws.ToString(resp.Arn)}, nil
+}
+
+// identities caches the AWS identity of each profile, see CallerIdentity.
+var identities sync.Map
+
+// CallerIdentity returns the AWS identity of the profile and error.
+//
+// The STS GetCallerIdentity API is called once per profile, the identity is cached for the next calls.
+// The errors are not cached.
+func CallerIdentity(ctx context.Context, profile, region string) (Identity, error) {
+	if identity, ok := identities.Load(profile); ok {
+		return identity.(Identity), nil
+	}
+	identity, err := callerIdentity(ctx, profile, region)
+	if err != nil {
+		return Identity{}, err
+	}
+	identities.Store(profile, identity)
+	return identity, nil
+}
+
+// AccountID returns the AWS account ID of the profile and error.
+//
+// The identity of the profile is cached, see CallerIdentity.
+func AccountID(ctx context.Context, profile, region string) (string, error) {
+	identity, err := CallerIdentity(ctx, profile, region)
 	if err != nil {
 		return "", err
 	}
-	return *resp.Account, nil
+	return identity.Account, nil
 }
 
 // defaultSharedConfigFilename is the default location of the AWS config file.
@@ -171,6 +249,18 @@ func FilterAvailabilityZones(availabilityZones []string, region string) []types.
 	}
 }
 
+// MaxFilterValues is the maximum number of values of an EC2 filter.
+const MaxFilterValues = 200
+
+// ChunkValues splits the filter values in chunks of at most size values, e.g. MaxFilterValues.
+func ChunkValues(values []string, size int) [][]string {
+	chunks := [][]string{}
+	for start := 0; start < len(values); start += size {
+		chunks = append(chunks, values[start:min(start+size, len(values))])
+	}
+	return chunks
+}
+
 // FilterDefault returns a list of types.Filter. The key is used as filter Name and the values as Values.
 func FilterDefault(key string, values []string) []types.Filter {
 	if key == "" || len(values) == 0 {
@@ -44,7 +44,7 @@ import (
 // 	}
 // 	for _, tt := range tests {
 // 		t.Run(tt.name, func(t *testing.T) {
-// 			got, err := AwsConfig(tt.args.profile, tt.args.region)
+// 			got, err := AwsConfig(context.Background(), tt.args.profile, tt.args.region)
 // 			if (err != nil) != tt.wantErr {
 // 				t.Errorf("AwsConfig() error = %v, wantErr %v", err, tt.wantErr)
 // 				return
@@ -72,7 +72,7 @@ import (
 // 	}
 // 	for _, tt := range tests {
 // 		t.Run(tt.name, func(t *testing.T) {
-// 			got, err := WhoAmI(tt.args.profile, tt.args.region)
+// 			got, err := WhoAmI(context.Background(), tt.args.profile, tt.args.region)
 // 			if (err != nil) != tt.wantErr {
 // 				t.Errorf("WhoAmI() error = %v, wantErr %v", err, tt.wantErr)
 // 				return
@@ -384,3 +384,24 @@ func TestFilterDefault(t *testing.T) {
 		})
 	}
 }
+
+// TestChunkValues tests the ChunkValues function.
+func TestChunkValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   [][]string
+	}{
+		{name: "no values", values: []string{}, want: [][]string{}},
+		{name: "one chunk", values: []string{"a", "b"}, want: [][]string{{"a", "b"}}},
+		{name: "full chunks", values: []string{"a", "b", "c", "d"}, want: [][]string{{"a", "b"}, {"c", "d"}}},
+		{name: "last chunk", values: []string{"a", "b", "c"}, want: [][]string{{"a", "b"}, {"c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChunkValues(tt.values, 2); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChunkValues()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// tagColumnPrefix is the prefix of the columns showing the value of a tag, e.g. `tag:Owner`.
+const tagColumnPrefix = "tag:"
+
+// columns is a map of the resource types to the columns selected for the table and csv outputs.
+//
+// The key is the type of the results, empty for the single resource searches.
+// It is set by SetColumns.
+var columns = map[string][]string{}
+
+// SetColumns selects and orders the columns of the table and csv outputs for the results of the given type.
+//
+// The type is empty for the single resource searches, see Results.GetType.
+// No columns means the default columns, i.e. the fields with a `header` tag.
+func SetColumns(resourceType string, cols []string) {
+	if len(cols) == 0 {
+		delete(columns, resourceType)
+		return
+	}
+	columns[resourceType] = cols
+}
+
+// CheckColumns returns an error if one of the columns is not valid for the given results.
+//
+// A column is the `json` tag of a row field, with dashes instead of underscores, e.g. `private-ip`.
+// The fields of a nested struct are columns too, e.g. the ENI `instance-id`.
+// A column can also be `tag:<key>`, to show the value of a tag.
+func CheckColumns(r Results, cols []string) error {
+	rowType, ok := rowType(r)
+	if !ok {
+		return fmt.Errorf("the %T results have no columns", r)
+	}
+	row := reflect.New(rowType).Elem()
+
+	for _, column := range cols {
+		if key, ok := strings.CutPrefix(column, tagColumnPrefix); ok {
+			if _, hasTags := columnValue(row, "tags"); !hasTags || key == "" {
+				return fmt.Errorf("invalid column: %s. It must be tag:<key>, for the rows with tags", column)
+			}
+			continue
+		}
+		if _, ok := columnValue(row, column); !ok {
+			return fmt.Errorf("invalid column: %s. The options are: %s, tag:<key>",
+				column, StringSliceToString(columnNames(rowType), ", "))
+		}
+	}
+	return nil
+}
+
+// rowType returns the type of the rows of the results, i.e. the elements of the Data field.
+func rowType(r Results) (reflect.Type, bool) {
+	v := reflect.Indirect(reflect.ValueOf(r))
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+	data := v.FieldByName("Data")
+	if !data.IsValid() || data.Kind() != reflect.Slice || data.Type().Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	return data.Type().Elem(), true
+}
+
+// columnNames returns the sorted names of the columns of a row type, including the nested struct fields.
+func columnNames(t reflect.Type) []string {
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := columnName(t.Field(i))
+		if !ok {
+			continue
+		}
+		names = append(names, name)
+		if t.Field(i).Type.Kind() == reflect.Struct {
+			names = append(names, columnNames(t.Field(i).Type)...)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// columnName returns the column name of a struct field, i.e. its `json` tag with dashes instead of underscores.
+//
+// It returns false if the field has no `json` tag, or if it is `-`.
+func columnName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" || name == "-" {
+		return "", false
+	}
+	return strings.ReplaceAll(name, "_", "-"), true
+}
+
+// columnHeader returns the header of a column.
+//
+// It is the `header` tag of the field, the tag key for a tag column, or the column name.
+func columnHeader(row reflect.Value, column string) string {
+	if key, ok := strings.CutPrefix(column, tagColumnPrefix); ok {
+		return key
+	}
+	if field, ok := columnField(row.Type(), column); ok {
+		if header, ok := field.Tag.Lookup("header"); ok {
+			return header
+		}
+	}
+	return column
+}
+
+// columnValue returns the value of a column in a row.
+//
+// The value of a tag column is the value of the tag, or an empty string.
+func columnValue(row reflect.Value, column string) (reflect.Value, bool) {
+	if key, ok := strings.CutPrefix(column, tagColumnPrefix); ok {
+		tags, ok := columnValue(row, "tags")
+		if !ok || tags.Kind() != reflect.Map {
+			return reflect.Value{}, false
+		}
+		value := tags.MapIndex(reflect.ValueOf(key))
+		if !value.IsValid() {
+			return reflect.ValueOf(""), true
+		}
+		return value, true
+	}
+
+	for i := 0; i < row.NumField(); i++ {
+		if name, ok := columnName(row.Type().Field(i)); ok && name == column {
+			return row.Field(i), true
+		}
+		if row.Field(i).Kind() != reflect.Struct {
+			continue
+		}
+		if value, ok := columnValue(row.Field(i), column); ok {
+			return value, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// columnField returns the struct field of a column, searching the nested structs too.
+func columnField(t reflect.Type, column string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if name, ok := columnName(t.Field(i)); ok && name == column {
+			return t.Field(i), true
+		}
+		if t.Field(i).Type.Kind() != reflect.Struct {
+			continue
+		}
+		if field, ok := columnField(t.Field(i).Type, column); ok {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// selectedColumns returns the columns selected for the results, or nil for the default columns.
+func selectedColumns(r Results) []string {
+	return columns[r.GetType()]
+}
+
+// headerFromColumns returns the table header of the given columns of the results.
+func headerFromColumns(r Results, cols []string) table.Row {
+	header := table.Row{}
+
+	t, ok := rowType(r)
+	if !ok {
+		for _, column := range cols {
+			header = append(header, column)
+		}
+		return header
+	}
+
+	row := reflect.New(t).Elem()
+	for _, column := range cols {
+		header = append(header, columnHeader(row, column))
+	}
+	return header
+}
+
+// rowFromColumns returns a table.Row with the given columns of a row, see cellFromValue.
+//
+// The columns not found in the row are empty.
+func rowFromColumns(i interface{}, cols []string, bold func(string) string) table.Row {
+	row := table.Row{}
+
+	v := reflect.ValueOf(i)
+	for _, column := range cols {
+		value, ok := columnValue(v, column)
+		if !ok {
+			row = append(row, "")
+			continue
+		}
+		row = append(row, cellFromValue(value, bold))
+	}
+	return row
+}
@@ -0,0 +1,156 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+// taggedRow is a row with tags and a nested struct, used to test the columns.
+type taggedRow struct {
+	ID   string            `json:"id,omitempty" header:"ID"`
+	Info testInfo          `json:"info,omitempty" header:"Info"`
+	Note string            `json:"note,omitempty"`
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// taggedResults is a testResults with taggedRow rows, used to test the columns.
+type taggedResults struct {
+	testResults
+	Data []taggedRow `json:"data"`
+}
+
+func (tr *taggedResults) Len() int { return len(tr.Data) }
+func (tr *taggedResults) GetRows() []interface{} {
+	rows := []interface{}{}
+	for _, row := range tr.Data {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// TestSetColumns tests the SetColumns function.
+func TestSetColumns(t *testing.T) {
+	// save the original columns and defer the restore
+	oldColumns := columns
+	defer func() { columns = oldColumns }()
+	columns = map[string][]string{}
+
+	SetColumns("", []string{"id"})
+	SetColumns("ec2", []string{"name"})
+	want := map[string][]string{"": {"id"}, "ec2": {"name"}}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("SetColumns()\n%#v\nwant\n%#v", columns, want)
+	}
+
+	SetColumns("", nil)
+	if got := selectedColumns(&testResults{}); got != nil {
+		t.Errorf("selectedColumns() after reset = %#v, want nil", got)
+	}
+}
+
+// TestCheckColumns tests the CheckColumns function.
+func TestCheckColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       Results
+		cols    []string
+		wantErr bool
+	}{
+		{name: "header and nested fields", r: &tr, cols: []string{"string-field", "info-string1", "struct-field"}},
+		{name: "field without header", r: &taggedResults{}, cols: []string{"note", "id"}},
+		{name: "tag column", r: &taggedResults{}, cols: []string{"tag:Owner"}},
+		{name: "invalid column", r: &tr, cols: []string{"invalid"}, wantErr: true},
+		{name: "tag column without tags", r: &tr, cols: []string{"tag:Owner"}, wantErr: true},
+		{name: "tag column without key", r: &taggedResults{}, cols: []string{"tag:"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckColumns(tt.r, tt.cols); (err != nil) != tt.wantErr {
+				t.Errorf("CheckColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_columnNames tests the columnNames function.
+func Test_columnNames(t *testing.T) {
+	want := []string{"id", "info", "info-string1", "info-string2", "note", "tags"}
+	if got := columnNames(reflect.TypeOf(taggedRow{})); !reflect.DeepEqual(got, want) {
+		t.Errorf("columnNames()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// Test_rowFromColumns tests the headerFromColumns and rowFromColumns functions.
+func Test_rowFromColumns(t *testing.T) {
+	r := &taggedResults{Data: []taggedRow{{
+		ID:   "id-1",
+		Info: testInfo{InfoString1: "one"},
+		Note: "note",
+		Tags: map[string]string{"Owner": "team"},
+	}}}
+	cols := []string{"tag:Owner", "info-string1", "note", "id", "tag:Missing"}
+
+	wantHeader := []interface{}{"Owner", "Info String1", "note", "ID", "Missing"}
+	if got := headerFromColumns(r, cols); !reflect.DeepEqual([]interface{}(got), wantHeader) {
+		t.Errorf("headerFromColumns()\n%#v\nwant\n%#v", got, wantHeader)
+	}
+	wantRow := []interface{}{"team", "one", "note", "id-1", ""}
+	if got := rowFromColumns(r.GetRows()[0], cols, Bold); !reflect.DeepEqual([]interface{}(got), wantRow) {
+		t.Errorf("rowFromColumns()\n%#v\nwant\n%#v", got, wantRow)
+	}
+}
+
+// Test_toTable_columns tests the toTable and toCSV functions with the selected columns.
+func Test_toTable_columns(t *testing.T) {
+	// save the original columns and functions, defer the restore and mock them
+	oldColumns := columns
+	oldBold := Bold
+	defer func() {
+		columns = oldColumns
+		Bold = oldBold
+	}()
+	columns = map[string][]string{}
+	Bold = func(s string) string { return s }
+
+	r := &taggedResults{
+		testResults: testResults{Profile: "p", Region: "r"},
+		Data: []taggedRow{{
+			ID:   "id-1",
+			Info: testInfo{InfoString1: "one", InfoString2: "two"},
+			Tags: map[string]string{"Owner": "team"},
+		}},
+	}
+	SetColumns("", []string{"id", "tag:Owner", "info"})
+
+	if got := toTable(r, false, false); got != tableColumns {
+		t.Errorf("toTable()\n%s\nwant\n%s", got, tableColumns)
+	}
+	wantCSV := "p,r,id-1,team,one,two"
+	if got := toCSV(r, false, false); got != wantCSV {
+		t.Errorf("toCSV()\n%#v\nwant\n%#v", got, wantCSV)
+	}
+	wantHeader := "Profile,Region,ID,Owner,Info String1,Info String2"
+	if got := csvHeader(r, false); got != wantHeader {
+		t.Errorf("csvHeader()\n%#v\nwant\n%#v", got, wantHeader)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"net"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"golang.org/x/term"
@@ -51,7 +52,8 @@ type Results interface {
 	Len() int
 	GetProfile() string
 	GetRegion() string
-	GetErrors() []string
+	GetType() string
+	GetErrors() []SearchError
 	GetSortField() string
 	GetHeaders() []interface{}
 	GetRows() []interface{}
@@ -75,6 +77,36 @@ func ParseTags(tags []string) (map[string][]string, error) {
 	return m, nil
 }
 
+// ParseBools parses the values of a true/false filter, e.g. "true,false".
+//
+// The key is the filter name, used in the error message.
+func ParseBools(key string, values []string) ([]bool, error) {
+	bools := make([]bool, 0, len(values))
+	for _, v := range values {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %s. The options are: true, false", key, v)
+		}
+		bools = append(bools, b)
+	}
+	return bools, nil
+}
+
+// MatchBool returns true if the value is one of the accepted values, e.g. parsed by ParseBools.
+//
+// An empty list of accepted values matches every value.
+func MatchBool(value bool, accepted []bool) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+	for _, a := range accepted {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
+
 // StringValue returns an empty string if the pointer is nil.
 func StringValue(s *string) string {
 	if s != nil {
@@ -114,6 +146,15 @@ func IPtoString(i []net.IP) []string {
 	return ips
 }
 
+// IntToString returns a slice of strings from a slice of ints.
+func IntToString(i []int) []string {
+	s := []string{}
+	for _, n := range i {
+		s = append(s, strconv.Itoa(n))
+	}
+	return s
+}
+
 // StructToFilters returns a map of filters from a struct.
 //
 // The struct must have the tag "filter" in the fields that should be used as filters.
@@ -131,6 +172,8 @@ func StructToFilters(s interface{}) (map[string][]string, error) {
 		switch reflect.TypeOf(v.Field(i).Interface()) {
 		case reflect.TypeOf([]net.IP{}):
 			filters[v.Type().Field(i).Tag.Get("filter")] = IPtoString(v.Field(i).Interface().([]net.IP))
+		case reflect.TypeOf([]int{}):
+			filters[v.Type().Field(i).Tag.Get("filter")] = IntToString(v.Field(i).Interface().([]int))
 		case reflect.TypeOf([]string{}):
 			filters[v.Type().Field(i).Tag.Get("filter")] = v.Field(i).Interface().([]string)
 		}
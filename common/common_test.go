@@ -30,6 +30,7 @@ import (
 type testStructToFilters struct {
 	SliceOfStringField []string `filter:"slice-of-string-field"`
 	NetIPField         []net.IP `filter:"net-ip-field"`
+	IntField           []int    `filter:"int-field"`
 	StringField        string   `filter:"string-field"`
 	FieldNotTagged     string
 }
@@ -57,6 +58,16 @@ func getStructToFiltersCases() []testStructToFiltersCase {
 			},
 			wantErr: false,
 		},
+		{
+			name: "slice of int",
+			input: testStructToFilters{
+				IntField: []int{22, 3389},
+			},
+			want: map[string][]string{
+				"int-field": {"22", "3389"},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "empty struct",
 			input:   testStructToFilters{},
@@ -152,6 +163,46 @@ func TestParseTags(t *testing.T) {
 	}
 }
 
+// TestParseBools tests the ParseBools function.
+func TestParseBools(t *testing.T) {
+	got, err := ParseBools("associated", []string{"true", "false"})
+	if err != nil {
+		t.Fatalf("ParseBools() unexpected error: %v", err)
+	}
+	if want := []bool{true, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseBools()\n%#v\nwant\n%#v", got, want)
+	}
+
+	want := "invalid associated value: maybe. The options are: true, false"
+	if _, err := ParseBools("associated", []string{"maybe"}); err == nil || err.Error() != want {
+		t.Errorf("ParseBools() error\n%v\nwant\n%v", err, want)
+	}
+}
+
+// TestMatchBool tests the MatchBool function.
+func TestMatchBool(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    bool
+		accepted []bool
+		want     bool
+	}{
+		{name: "no filter", value: false, accepted: nil, want: true},
+		{name: "true only, true value", value: true, accepted: []bool{true}, want: true},
+		{name: "true only, false value", value: false, accepted: []bool{true}, want: false},
+		{name: "false only, false value", value: false, accepted: []bool{false}, want: true},
+		{name: "false only, true value", value: true, accepted: []bool{false}, want: false},
+		{name: "both", value: true, accepted: []bool{true, false}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchBool(tt.value, tt.accepted); got != tt.want {
+				t.Errorf("MatchBool()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestStringValue tests the StringValue function.
 func TestStringValue(t *testing.T) {
 	// Variables to test the pointer
@@ -337,6 +388,25 @@ func TestIPtoString(t *testing.T) {
 	}
 }
 
+// TestIntToString tests the IntToString function.
+func TestIntToString(t *testing.T) {
+	tests := []struct {
+		name string
+		i    []int
+		want []string
+	}{
+		{name: "empty", i: []int{}, want: []string{}},
+		{name: "two ints", i: []int{22, 443}, want: []string{"22", "443"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IntToString(tt.i); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IntToString()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestStructToFilters tests the StructToFilters function.
 func TestStructToFilters(t *testing.T) {
 	for _, tt := range getStructToFiltersCases() {
@@ -0,0 +1,270 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// ErrorCategory is the category of a search error.
+type ErrorCategory string
+
+const (
+	// CategoryAuth is the category of the authentication errors, e.g. expired or invalid credentials.
+	CategoryAuth ErrorCategory = "auth"
+	// CategoryAccessDenied is the category of the errors of the operations the credentials are not allowed to call.
+	CategoryAccessDenied ErrorCategory = "access-denied"
+	// CategoryThrottled is the category of the errors of the throttled API calls.
+	CategoryThrottled ErrorCategory = "throttled"
+	// CategoryRegionDisabled is the category of the errors of the regions not enabled in the account.
+	CategoryRegionDisabled ErrorCategory = "region-disabled"
+	// CategoryInvalidFilter is the category of the errors of the invalid filters or parameters.
+	CategoryInvalidFilter ErrorCategory = "invalid-filter"
+	// CategoryNetwork is the category of the errors sending the requests or reading the responses.
+	CategoryNetwork ErrorCategory = "network"
+	// CategoryNotFound is the category of the errors of the resources not found, e.g. InvalidInstanceID.NotFound.
+	CategoryNotFound ErrorCategory = "not-found"
+	// CategoryCancelled is the category of the errors of the searches cancelled or timed out.
+	CategoryCancelled ErrorCategory = "cancelled"
+	// CategoryOther is the category of the errors not in any other category.
+	CategoryOther ErrorCategory = "other"
+)
+
+// errorCategories is the order the error categories are shown in the table outputs.
+var errorCategories = []ErrorCategory{
+	CategoryAuth,
+	CategoryAccessDenied,
+	CategoryRegionDisabled,
+	CategoryThrottled,
+	CategoryInvalidFilter,
+	CategoryNetwork,
+	CategoryNotFound,
+	CategoryCancelled,
+	CategoryOther,
+}
+
+// errorCodeCategories maps the AWS API error codes to their category.
+//
+// The codes ending in NotFound are not listed, see errorCodeCategory.
+var errorCodeCategories = map[string]ErrorCategory{
+	"AuthFailure":                 CategoryAuth,
+	"ExpiredToken":                CategoryAuth,
+	"ExpiredTokenException":       CategoryAuth,
+	"IncompleteSignature":         CategoryAuth,
+	"InvalidClientTokenId":        CategoryAuth,
+	"InvalidGrantException":       CategoryAuth,
+	"InvalidToken":                CategoryAuth,
+	"MissingAuthenticationToken":  CategoryAuth,
+	"RequestExpired":              CategoryAuth,
+	"SignatureDoesNotMatch":       CategoryAuth,
+	"UnrecognizedClientException": CategoryAuth,
+	"AccessDenied":                CategoryAccessDenied,
+	"AccessDeniedException":       CategoryAccessDenied,
+	"UnauthorizedOperation":       CategoryAccessDenied,
+	"RequestLimitExceeded":        CategoryThrottled,
+	"RequestThrottled":            CategoryThrottled,
+	"RequestThrottledException":   CategoryThrottled,
+	"SlowDown":                    CategoryThrottled,
+	"Throttling":                  CategoryThrottled,
+	"ThrottlingException":         CategoryThrottled,
+	"TooManyRequestsException":    CategoryThrottled,
+	"OptInRequired":               CategoryRegionDisabled,
+	"RegionDisabledException":     CategoryRegionDisabled,
+	"InvalidFilter":               CategoryInvalidFilter,
+	"InvalidParameter":            CategoryInvalidFilter,
+	"InvalidParameterCombination": CategoryInvalidFilter,
+	"InvalidParameterException":   CategoryInvalidFilter,
+	"InvalidParameterValue":       CategoryInvalidFilter,
+	"ValidationException":         CategoryInvalidFilter,
+}
+
+// SearchError is an error found during a search.
+type SearchError struct {
+	// Category is the category of the error, e.g. throttled.
+	Category ErrorCategory `json:"category"`
+
+	// Operation is the API operation which failed, e.g. EC2:DescribeInstances. It is empty if no API call failed.
+	Operation string `json:"operation,omitempty"`
+
+	// RequestID is the ID of the failed API request. It is empty if the request got no response.
+	RequestID string `json:"request_id,omitempty"`
+
+	// Message is the error message.
+	Message string `json:"message"`
+}
+
+// Error returns the error message.
+func (e SearchError) Error() string { return e.Message }
+
+// NewSearchError returns the SearchError of an error.
+//
+// The operation and the request ID are taken from the AWS SDK errors wrapped by err.
+// If err wraps a SearchError, e.g. from InvalidFilterError, its category, operation and request ID are kept.
+func NewSearchError(err error) SearchError {
+	var e SearchError
+	if errors.As(err, &e) {
+		e.Message = err.Error()
+		return e
+	}
+
+	e = SearchError{Category: errorCategory(err), Message: err.Error()}
+
+	var opErr *smithy.OperationError
+	if errors.As(err, &opErr) {
+		e.Operation = fmt.Sprintf("%s:%s", opErr.Service(), opErr.Operation())
+	}
+	var reqErr interface{ ServiceRequestID() string }
+	if errors.As(err, &reqErr) {
+		e.RequestID = reqErr.ServiceRequestID()
+	}
+	return e
+}
+
+// InvalidFilterError returns err as a SearchError of the invalid filter category.
+//
+// It is used for the filters which are found invalid before calling the API.
+func InvalidFilterError(err error) error {
+	return SearchError{Category: CategoryInvalidFilter, Message: err.Error()}
+}
+
+// JoinSearchErrors returns one error wrapping all the errors, see errors.Join.
+//
+// The SearchError of the joined error has the category, the operation and the request ID of the first error.
+func JoinSearchErrors(errs []SearchError) error {
+	joined := make([]error, len(errs))
+	for i, e := range errs {
+		joined[i] = e
+	}
+	return errors.Join(joined...)
+}
+
+// errorCategory returns the category of an error.
+//
+// The expired or invalid SSO sessions are auth errors, whatever error the SSO token refresh returned.
+// The category of the AWS API errors is taken from their error code, see errorCodeCategory.
+// The other errors are classified by their type.
+func errorCategory(err error) ErrorCategory {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return CategoryCancelled
+	}
+	var tokenErr *ssocreds.InvalidTokenError
+	if errors.As(err, &tokenErr) {
+		return CategoryAuth
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return errorCodeCategory(apiErr.ErrorCode())
+	}
+
+	var signingErr *v4.SigningError
+	var profileErr config.SharedConfigProfileNotExistError
+	var sendErr *smithyhttp.RequestSendError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &signingErr), errors.As(err, &profileErr):
+		return CategoryAuth
+	case errors.As(err, &sendErr), errors.As(err, &netErr):
+		return CategoryNetwork
+	default:
+		return CategoryOther
+	}
+}
+
+// errorCodeCategory returns the category of an AWS API error code.
+//
+// The codes ending in NotFound, e.g. InvalidInstanceID.NotFound, are not found errors.
+// The codes ending in Malformed, e.g. InvalidInstanceID.Malformed, are invalid filter errors.
+func errorCodeCategory(code string) ErrorCategory {
+	if category, ok := errorCodeCategories[code]; ok {
+		return category
+	}
+	switch {
+	case strings.HasSuffix(code, "NotFound"):
+		return CategoryNotFound
+	case strings.HasSuffix(code, ".Malformed"):
+		return CategoryInvalidFilter
+	default:
+		return CategoryOther
+	}
+}
+
+// errorsToString returns the errors grouped by category, in the order of errorCategories.
+//
+// The string is presented in the format:
+// [<category>]
+// <message>
+// <message>
+// ...
+// bold is the function used to bold the categories.
+func errorsToString(errs []SearchError, bold func(string) string) string {
+	var s []string
+	for _, group := range groupErrors(errs) {
+		s = append(s, bold(fmt.Sprintf("[%s]", group[0].Category)))
+		for _, e := range group {
+			s = append(s, e.Message)
+		}
+	}
+	return StringSliceToString(s, "\n")
+}
+
+// groupErrors returns the errors grouped by category, in the order of errorCategories.
+//
+// The errors of the categories not in errorCategories are grouped at the end, in the order they are found.
+func groupErrors(errs []SearchError) [][]SearchError {
+	order := map[ErrorCategory]int{}
+	for i, c := range errorCategories {
+		order[c] = i
+	}
+
+	groups := [][]SearchError{}
+	index := map[ErrorCategory]int{}
+	for _, e := range errs {
+		i, ok := index[e.Category]
+		if !ok {
+			i = len(groups)
+			index[e.Category] = i
+			groups = append(groups, []SearchError{})
+		}
+		groups[i] = append(groups[i], e)
+	}
+
+	rank := func(c ErrorCategory) int {
+		if r, ok := order[c]; ok {
+			return r
+		}
+		return len(errorCategories)
+	}
+	sort.SliceStable(groups, func(p, q int) bool {
+		return rank(groups[p][0].Category) < rank(groups[q][0].Category)
+	})
+	return groups
+}
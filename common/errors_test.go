@@ -0,0 +1,168 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// apiError returns an AWS SDK error of the EC2 DescribeInstances operation with the given error code.
+func apiError(code string) error {
+	return &smithy.OperationError{
+		ServiceID:     "EC2",
+		OperationName: "DescribeInstances",
+		Err: &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusBadRequest}},
+				Err:      &smithy.GenericAPIError{Code: code, Message: "testMessage"},
+			},
+			RequestID: "testRequestID",
+		},
+	}
+}
+
+// TestNewSearchError is a test function for NewSearchError.
+func TestNewSearchError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want SearchError
+	}{
+		{
+			name: "throttled API error",
+			err:  fmt.Errorf("error describing instances: %w", apiError("RequestLimitExceeded")),
+			want: SearchError{Category: CategoryThrottled, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "expired credentials",
+			err:  apiError("ExpiredToken"),
+			want: SearchError{Category: CategoryAuth, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "expired SSO session",
+			err: &smithy.OperationError{
+				ServiceID: "STS", OperationName: "GetCallerIdentity",
+				Err: fmt.Errorf("get identity: get credentials: failed to refresh cached credentials, %w",
+					&ssocreds.InvalidTokenError{Err: errors.New("token expired")}),
+			},
+			want: SearchError{Category: CategoryAuth, Operation: "STS:GetCallerIdentity"},
+		},
+		{
+			name: "SSO session refresh rejected",
+			err: &smithy.OperationError{
+				ServiceID: "STS", OperationName: "GetCallerIdentity",
+				Err: fmt.Errorf("get identity: get credentials: failed to refresh cached credentials, %w",
+					&ssocreds.InvalidTokenError{Err: &smithy.GenericAPIError{Code: "InvalidGrantException"}}),
+			},
+			want: SearchError{Category: CategoryAuth, Operation: "STS:GetCallerIdentity"},
+		},
+		{
+			name: "SSO OIDC invalid grant",
+			err:  apiError("InvalidGrantException"),
+			want: SearchError{Category: CategoryAuth, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "access denied",
+			err:  apiError("UnauthorizedOperation"),
+			want: SearchError{Category: CategoryAccessDenied, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "region disabled",
+			err:  apiError("OptInRequired"),
+			want: SearchError{Category: CategoryRegionDisabled, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "malformed ID",
+			err:  apiError("InvalidInstanceID.Malformed"),
+			want: SearchError{Category: CategoryInvalidFilter, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "not found",
+			err:  apiError("InvalidInstanceID.NotFound"),
+			want: SearchError{Category: CategoryNotFound, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "network error",
+			err: &smithy.OperationError{
+				ServiceID: "EC2", OperationName: "DescribeInstances",
+				Err: &smithyhttp.RequestSendError{Err: errors.New("connection refused")},
+			},
+			want: SearchError{Category: CategoryNetwork, Operation: "EC2:DescribeInstances"},
+		},
+		{
+			name: "cancelled",
+			err:  fmt.Errorf("search cancelled: %w", context.Canceled),
+			want: SearchError{Category: CategoryCancelled},
+		},
+		{
+			name: "invalid filter",
+			err:  fmt.Errorf("error building filters: %w", InvalidFilterError(errors.New("invalid tag"))),
+			want: SearchError{Category: CategoryInvalidFilter},
+		},
+		{
+			name: "joined errors",
+			err: fmt.Errorf("error searching instance names: %w", JoinSearchErrors([]SearchError{
+				NewSearchError(apiError("RequestLimitExceeded")),
+				NewSearchError(errors.New("testError")),
+			})),
+			want: SearchError{Category: CategoryThrottled, Operation: "EC2:DescribeInstances", RequestID: "testRequestID"},
+		},
+		{
+			name: "other error",
+			err:  errors.New("testError"),
+			want: SearchError{Category: CategoryOther},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Message = tt.err.Error()
+			if got := NewSearchError(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSearchError()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_errorsToString is a test function for errorsToString.
+func Test_errorsToString(t *testing.T) {
+	errs := []SearchError{
+		{Category: CategoryOther, Message: "testError1"},
+		{Category: CategoryThrottled, Message: "testError2"},
+		{Category: "unknown", Message: "testError3"},
+		{Category: CategoryThrottled, Message: "testError4"},
+		{Category: CategoryAuth, Message: "testError5"},
+	}
+	want := "[auth]\ntestError5\n[throttled]\ntestError2\ntestError4\n[other]\ntestError1\n[unknown]\ntestError3"
+
+	if got := errorsToString(errs, func(s string) string { return s }); got != want {
+		t.Errorf("errorsToString()\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Limits are the limits of the AWS API calls made with the configs of AwsConfig.
+type Limits struct {
+	// RateLimit is the number of EC2 API calls per second allowed for each account and region. Zero means no limit.
+	RateLimit int
+
+	// MaxAttempts is the maximum number of attempts of an API call with the adaptive retry mode.
+	// Zero means the default of the AWS SDK.
+	MaxAttempts int
+}
+
+// limits are the limits of the AWS API calls, see SetLimits.
+var limits = Limits{}
+
+// buckets are the token buckets of the EC2 API calls of each account and region, see rateLimiter.
+var buckets sync.Map
+
+// retryers are the adaptive retryers of each account and region, see sharedRetryer.
+var retryers sync.Map
+
+// SetLimits sets the limits of the AWS API calls.
+//
+// It must be called before the first call to AwsConfig, the token buckets and the retryers already created are kept.
+func SetLimits(l Limits) {
+	limits = l
+}
+
+// retryer returns an adaptive mode retryer with the maximum number of attempts of the limits.
+//
+// The adaptive mode slows the API calls down when they are throttled, instead of failing.
+func retryer() aws.Retryer {
+	return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+		if limits.MaxAttempts > 0 {
+			o.StandardOptions = append(o.StandardOptions, func(so *retry.StandardOptions) {
+				so.MaxAttempts = limits.MaxAttempts
+			})
+		}
+	})
+}
+
+// sharedRetryer returns the retryer of the clients with the given key, see limitsKey.
+//
+// The clients of all the profiles of an account in a region share the same adaptive retryer,
+// so they slow down together when the account is throttled in the region.
+func sharedRetryer(key string) func() aws.Retryer {
+	r, ok := retryers.Load(key)
+	if !ok {
+		r, _ = retryers.LoadOrStore(key, retryer())
+	}
+	return func() aws.Retryer { return r.(aws.Retryer) }
+}
+
+// rateLimiter returns an API option waiting for a token of the bucket with the given key, see limitsKey,
+// before each EC2 API call attempt.
+//
+// The EC2 clients of all the profiles of an account in a region share the same bucket.
+// It returns nil if there is no rate limit.
+func rateLimiter(key string) func(*middleware.Stack) error {
+	if limits.RateLimit <= 0 {
+		return nil
+	}
+	b, ok := buckets.Load(key)
+	if !ok {
+		b, _ = buckets.LoadOrStore(key, newTokenBucket(limits.RateLimit))
+	}
+	bucket := b.(*tokenBucket)
+
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("RateLimiter",
+			func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+				middleware.FinalizeOutput, middleware.Metadata, error,
+			) {
+				if awsmiddleware.GetServiceID(ctx) == ec2.ServiceID {
+					if err := bucket.wait(ctx); err != nil {
+						return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+					}
+				}
+				return next.HandleFinalize(ctx, in)
+			}), middleware.After)
+	}
+}
+
+// tokenBucket is a token bucket allowing rate calls per second, with bursts of up to rate calls.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full token bucket allowing rate calls per second.
+func newTokenBucket(rate int) *tokenBucket {
+	return &tokenBucket{rate: float64(rate), tokens: float64(rate), last: time.Now()}
+}
+
+// wait takes a token from the bucket, waiting until it is available or the context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token from the bucket at the given time and returns how long to wait until it is available.
+//
+// The bucket is refilled with the time elapsed since the last call. The tokens can go negative,
+// so the calls waiting for a token are spread at the rate of the bucket.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.After(b.last) {
+		b.tokens = math.Min(b.rate, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Test_tokenBucket_reserve is a test function for tokenBucket.reserve.
+func Test_tokenBucket_reserve(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &tokenBucket{rate: 2, tokens: 2, last: start}
+
+	tests := []struct {
+		name string
+		at   time.Duration
+		want time.Duration
+	}{
+		{name: "first token of the burst", at: 0, want: 0},
+		{name: "second token of the burst", at: 0, want: 0},
+		{name: "empty bucket", at: 0, want: 500 * time.Millisecond},
+		{name: "waiting calls are spread", at: 0, want: time.Second},
+		{name: "refilled bucket", at: 2 * time.Second, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.reserve(start.Add(tt.at)); got != tt.want {
+				t.Errorf("reserve()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_tokenBucket_wait is a test function for tokenBucket.wait with a done context.
+func Test_tokenBucket_wait(t *testing.T) {
+	b := newTokenBucket(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if err := b.wait(ctx); err != nil {
+		t.Fatalf("wait() unexpected error: %v", err)
+	}
+	cancel()
+	if err := b.wait(ctx); err == nil {
+		t.Error("wait() expected error with a done context, got nil")
+	}
+}
+
+// Test_rateLimiter is a test function for rateLimiter.
+func Test_rateLimiter(t *testing.T) {
+	// save the original limits, defer the restore and set the limits
+	oldLimits := limits
+	defer func() { limits = oldLimits }()
+
+	SetLimits(Limits{})
+	if rateLimiter("noLimit") != nil {
+		t.Error("rateLimiter() expected nil without rate limit")
+	}
+
+	SetLimits(Limits{RateLimit: 1})
+	if rateLimiter("limited") == nil {
+		t.Error("rateLimiter() expected an API option with a rate limit")
+	}
+	first, _ := buckets.Load("limited")
+	rateLimiter("limited")
+	if second, _ := buckets.Load("limited"); first != second {
+		t.Error("rateLimiter() expected the same bucket for the same key")
+	}
+}
+
+// Test_sharedRetryer is a test function for sharedRetryer.
+func Test_sharedRetryer(t *testing.T) {
+	first := sharedRetryer("111111111111/us-east-1")()
+	if second := sharedRetryer("111111111111/us-east-1")(); first != second {
+		t.Error("sharedRetryer() expected the same retryer for the same key")
+	}
+	if other := sharedRetryer("111111111111/eu-west-1")(); first == other {
+		t.Error("sharedRetryer() expected another retryer for another region")
+	}
+}
+
+// Test_limitsKey is a test function for limitsKey.
+func Test_limitsKey(t *testing.T) {
+	// cache an identity and defer its removal
+	identities.Store("limitsKeyProfile", Identity{Account: "123456789012"})
+	defer identities.Delete("limitsKeyProfile")
+
+	tests := []struct {
+		name    string
+		profile string
+		want    string
+	}{
+		{name: "cached identity", profile: "limitsKeyProfile", want: "123456789012/us-east-1"},
+		{name: "no cached identity", profile: "noIdentity", want: "profile:noIdentity/us-east-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limitsKey(tt.profile, "us-east-1"); got != tt.want {
+				t.Errorf("limitsKey()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_retryer is a test function for retryer.
+func Test_retryer(t *testing.T) {
+	// save the original limits, defer the restore and set the limits
+	oldLimits := limits
+	defer func() { limits = oldLimits }()
+
+	tests := []struct {
+		name        string
+		maxAttempts int
+		want        int
+	}{
+		{name: "default attempts", maxAttempts: 0, want: 3},
+		{name: "max attempts", maxAttempts: 10, want: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetLimits(Limits{MaxAttempts: tt.maxAttempts})
+			if got := retryer().MaxAttempts(); got != tt.want {
+				t.Errorf("retryer().MaxAttempts()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
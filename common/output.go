@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"reflect"
 	"sort"
 
@@ -38,6 +39,26 @@ const (
 	JSONPretty = "json-pretty"
 	// Table is the table output format.
 	Table = "table"
+	// CSV is the CSV output format.
+	CSV = "csv"
+	// TSV is the TSV output format.
+	TSV = "tsv"
+	// YAML is the YAML output format.
+	YAML = "yaml"
+	// YAMLStream is the multi-document YAML output format.
+	YAMLStream = "yaml-stream"
+	// Template is the Go text/template output format, see SetTemplate.
+	Template = "template"
+	// NDJSON is the newline delimited JSON output format, with one JSON object per row.
+	NDJSON = "ndjson"
+	// JSONDocument is the JSON output format printing one document with the search metadata, see SetMetadata.
+	JSONDocument = "json-document"
+	// TableMerged is the table output format merging the results of all profiles and regions in one table.
+	TableMerged = "table-merged"
+	// Markdown is the markdown output format, with a heading and a table per result.
+	Markdown = "markdown"
+	// HTML is the HTML output format, with a heading and a table per result.
+	HTML = "html"
 )
 
 // outputs is a map of output formats to functions that print the results in the given format.
@@ -48,6 +69,14 @@ var outputs = map[string]func(Results, bool, bool) string{
 	JSON:       toJSON,
 	JSONPretty: toJSONPretty,
 	Table:      toTable,
+	CSV:        toCSV,
+	TSV:        toTSV,
+	YAML:       toYAML,
+	YAMLStream: toYAMLStream,
+	Template:   toTemplate,
+	NDJSON:     toNDJSON,
+	Markdown:   toMarkdown,
+	HTML:       toHTML,
 }
 
 // ValidOutputs returns the valid output formats and if the given output is valid.
@@ -56,9 +85,41 @@ func ValidOutputs(o string) (string, bool) {
 	for k := range outputs {
 		valid = append(valid, k)
 	}
+	for k := range mergedOutputs {
+		valid = append(valid, k)
+	}
 	sort.Strings(valid)
 	_, ok := outputs[o]
-	return StringSliceToString(valid, ", "), ok
+	_, merged := mergedOutputs[o]
+	return StringSliceToString(valid, ", "), ok || merged
+}
+
+// errWriter is the writer of the errors of the output formats which cannot show them, e.g. csv.
+//
+// We use this var to allow tests to mock the writer.
+var errWriter io.Writer = os.Stderr
+
+// errorsToErrWriter lists the output formats which cannot show the errors of the results.
+//
+// Their errors are printed to errWriter instead.
+var errorsToErrWriter = map[string]bool{
+	CSV:      true,
+	TSV:      true,
+	Template: true,
+	NDJSON:   true,
+}
+
+// streamingOutputs lists the output formats which print the results as soon as each search is done by default.
+//
+// The other formats print the results in the order of the profiles and regions.
+var streamingOutputs = map[string]bool{
+	NDJSON:     true,
+	YAMLStream: true,
+}
+
+// StreamingOutput returns if the given output format prints the results as soon as each search is done by default.
+func StreamingOutput(o string) bool {
+	return streamingOutputs[o]
 }
 
 // PrintResults prints the results in the given format.
@@ -68,7 +129,24 @@ func ValidOutputs(o string) (string, bool) {
 // The output is the format of the output.
 // The showEmpty flag indicates if empty results should be shown.
 // The showTags flag indicates if the tags should be shown.
+//
+// For the formats in mergedOutputs, all the results are read before being printed at once.
+// For the formats in outputHeaders, the header is printed once, before the first rows.
+// For the formats in errorsToErrWriter, the errors are printed to errWriter.
 func PrintResults(w io.Writer, resultsChan <-chan Results, done chan<- bool, output string, showEmpty, showTags bool) {
+	if printMerged, ok := mergedOutputs[output]; ok {
+		collected := []Results{}
+		for results := range resultsChan {
+			collected = append(collected, results)
+		}
+		if s := printMerged(collected, showEmpty, showTags); s != "" {
+			fmt.Fprintln(w, s)
+		}
+		done <- true
+		return
+	}
+
+	lastHeader := ""
 	for results := range resultsChan {
 		printResults, ok := outputs[output]
 		if !ok {
@@ -76,6 +154,15 @@ func PrintResults(w io.Writer, resultsChan <-chan Results, done chan<- bool, out
 			continue
 		}
 		s := printResults(results, showEmpty, showTags)
+		if errorsToErrWriter[output] {
+			printErrors(errWriter, results)
+		}
+		if header, ok := outputHeaders[output]; ok {
+			if h := header(results, showTags); s != "" && h != lastHeader {
+				fmt.Fprintln(w, h)
+				lastHeader = h
+			}
+		}
 		if s != "" {
 			fmt.Fprintln(w, s)
 		}
@@ -83,6 +170,13 @@ func PrintResults(w io.Writer, resultsChan <-chan Results, done chan<- bool, out
 	done <- true
 }
 
+// printErrors prints each error of the results in a line, prefixed by the profile and the region.
+func printErrors(w io.Writer, r Results) {
+	for _, e := range r.GetErrors() {
+		fmt.Fprintf(w, "[%s] [%s] %s\n", r.GetProfile(), r.GetRegion(), e.Message)
+	}
+}
+
 // Bold is the function used to bold text.
 //
 // We use this var to allow tests to mock the function.
@@ -130,6 +224,7 @@ func toJSONPretty(r Results, showEmpty, showTags bool) string {
 
 // toTable returns the results in table format.
 //
+// The errors are shown in the title, grouped by category.
 // showEmpty indicates if empty results should be shown.
 // showTags indicates if the tags should be shown.
 func toTable(r Results, showEmpty, showTags bool) string {
@@ -137,40 +232,59 @@ func toTable(r Results, showEmpty, showTags bool) string {
 		return ""
 	}
 
-	tableStyle := table.StyleDefault
-	tableStyle.Format.Header = text.FormatDefault
-	tableStyle.Title.Align = text.AlignLeft
-
-	t := table.NewWriter()
-	t.SetStyle(tableStyle)
-	t.SetAllowedRowLength(getTerminalSize().Width)
-	t.Style().Options.SeparateRows = true
-
-	errors := r.GetErrors()
 	showErrors := ""
-	if len(errors) > 0 {
-		showErrors = fmt.Sprintf("\n\n%s", StringSliceToString(errors, "\n"))
+	if errors := r.GetErrors(); len(errors) > 0 {
+		showErrors = fmt.Sprintf("\n\n%s", errorsToString(errors, Bold))
 	}
 
+	t := tableWriter(r, showTags, Bold)
+	t.SetTitle(fmt.Sprintf("%s %s", tableTitle(r, Bold), showErrors))
+
+	return fmt.Sprintf("%s\n", t.Render())
+}
+
+// tableTitle returns the title of the table of the results, with the type, the profile, the region and the sort field.
+//
+// bold is the function used to bold the labels.
+func tableTitle(r Results, bold func(string) string) string {
 	showSort := ""
 	if s := r.GetSortField(); s != "" {
-		showSort = fmt.Sprintf("%s %s", Bold("[Sort]"), s)
+		showSort = fmt.Sprintf("%s %s", bold("[Sort]"), s)
 	}
 
-	t.SetTitle(
-		fmt.Sprintf("%s %s %s %s %s %s",
-			Bold("[Profile]"),
-			r.GetProfile(),
-			Bold("[Region]"),
-			r.GetRegion(),
-			showSort,
-			showErrors),
-	)
+	showType := ""
+	if rt := r.GetType(); rt != "" {
+		showType = fmt.Sprintf("%s %s ", bold("[Type]"), rt)
+	}
+
+	return fmt.Sprintf("%s%s %s %s %s %s",
+		showType,
+		bold("[Profile]"),
+		r.GetProfile(),
+		bold("[Region]"),
+		r.GetRegion(),
+		showSort)
+}
+
+// tableWriter returns a table writer with the header and the rows of the results, without title.
+//
+// showTags indicates if the tags should be shown.
+// bold is the function used to bold the keys of the cells, see cellFromValue.
+func tableWriter(r Results, showTags bool, bold func(string) string) table.Writer {
+	t := newTableWriter()
+
+	if cols := selectedColumns(r); cols != nil {
+		t.AppendHeader(headerFromColumns(r, cols))
+		for _, d := range r.GetRows() {
+			t.AppendRow(rowFromColumns(d, cols, bold))
+		}
+		return t
+	}
 
 	t.AppendHeader(r.GetHeaders())
 
 	for _, d := range r.GetRows() {
-		t.AppendRow(rowFromStruct(d))
+		t.AppendRow(rowFromStruct(d, bold))
 	}
 
 	t.SetColumnConfigs(
@@ -179,15 +293,27 @@ func toTable(r Results, showEmpty, showTags bool) string {
 		},
 	)
 
-	return fmt.Sprintf("%s\n", t.Render())
+	return t
+}
+
+// newTableWriter returns a table writer with the style of the table outputs.
+func newTableWriter() table.Writer {
+	tableStyle := table.StyleDefault
+	tableStyle.Format.Header = text.FormatDefault
+	tableStyle.Title.Align = text.AlignLeft
+
+	t := table.NewWriter()
+	t.SetStyle(tableStyle)
+	t.SetAllowedRowLength(getTerminalSize().Width)
+	t.Style().Options.SeparateRows = true
+
+	return t
 }
 
 // RowsFromStruct returns a table.Row from a struct.
 //
-// If the field is a map, it calls mapToTable.
-// If the field is a slice, it joins the elements with a new line.
-// If the field is a struct, it calls structToTable.
-func rowFromStruct(i interface{}) table.Row {
+// Each field with a `header` tag is a cell, see cellFromValue.
+func rowFromStruct(i interface{}, bold func(string) string) table.Row {
 	row := table.Row{}
 
 	v := reflect.ValueOf(i)
@@ -195,21 +321,31 @@ func rowFromStruct(i interface{}) table.Row {
 		field := v.Field(i)
 
 		if _, ok := v.Type().Field(i).Tag.Lookup("header"); ok {
-			switch field.Kind() {
-			case reflect.Struct:
-				row = append(row, headerStructFieldsToString(field.Interface()))
-			case reflect.Map:
-				row = append(row, sortedStringMapToString(field.Interface().(map[string]string)))
-			case reflect.Slice:
-				row = append(row, sortedStringSliceToString(field.Interface().([]string)))
-			default:
-				row = append(row, field.Interface())
-			}
+			row = append(row, cellFromValue(field, bold))
 		}
 	}
 	return row
 }
 
+// cellFromValue returns the table cell of a struct field value.
+//
+// If the field is a map, it calls sortedStringMapToString.
+// If the field is a slice, it calls sortedStringSliceToString.
+// If the field is a struct, it calls headerStructFieldsToString.
+// bold is the function used to bold the keys of maps and structs.
+func cellFromValue(field reflect.Value, bold func(string) string) interface{} {
+	switch field.Kind() {
+	case reflect.Struct:
+		return headerStructFieldsToString(field.Interface(), bold)
+	case reflect.Map:
+		return sortedStringMapToString(field.Interface().(map[string]string), bold)
+	case reflect.Slice:
+		return sortedStringSliceToString(field.Interface().([]string))
+	default:
+		return field.Interface()
+	}
+}
+
 // headerStructFieldsToString returns a string from a struct.
 //
 // Headers are used from the struct tag `header:"<header>"`.
@@ -217,7 +353,7 @@ func rowFromStruct(i interface{}) table.Row {
 // <header>: <value>
 // <header>: <value>
 // ...
-func headerStructFieldsToString(i interface{}) string {
+func headerStructFieldsToString(i interface{}, bold func(string) string) string {
 	var s []string
 
 	v := reflect.ValueOf(i)
@@ -225,7 +361,7 @@ func headerStructFieldsToString(i interface{}) string {
 		field := v.Field(i)
 
 		if header, ok := v.Type().Field(i).Tag.Lookup("header"); ok && field.Interface() != "" {
-			s = append(s, fmt.Sprintf("%s: %s", Bold(header), v.Field(i).Interface()))
+			s = append(s, fmt.Sprintf("%s: %s", bold(header), v.Field(i).Interface()))
 		}
 	}
 
@@ -239,11 +375,11 @@ func headerStructFieldsToString(i interface{}) string {
 // <key>: <value>
 // <key>: <value>
 // ...
-func sortedStringMapToString(m map[string]string) string {
+func sortedStringMapToString(m map[string]string, bold func(string) string) string {
 	var s []string
 
 	for k, v := range m {
-		s = append(s, fmt.Sprintf("%s: %s", Bold(k), v))
+		s = append(s, fmt.Sprintf("%s: %s", bold(k), v))
 	}
 
 	sort.Strings(s)
@@ -0,0 +1,61 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// Credentials is the result of the credentials check of a profile.
+type Credentials struct {
+	// Profile is the profile checked.
+	Profile string
+
+	// Identity is the AWS identity of the profile. It is empty if the check failed.
+	Identity Identity
+
+	// Err is the error of the check, or nil if the credentials are valid.
+	Err error
+}
+
+// CredentialsTable returns the credential status of the profiles in a table.
+//
+// The table has one row per profile, without separators.
+// The status is ok, or the category and the message of the error, see NewSearchError.
+func CredentialsTable(credentials []Credentials) string {
+	t := newTableWriter()
+	t.Style().Options.SeparateRows = false
+	t.SetTitle(Bold("[Credentials]"))
+	t.AppendHeader(table.Row{"Profile", "Account", "ARN", "Status"})
+
+	for _, c := range credentials {
+		status := "ok"
+		if c.Err != nil {
+			e := NewSearchError(c.Err)
+			status = fmt.Sprintf("[%s] %s", e.Category, e.Message)
+		}
+		t.AppendRow(table.Row{c.Profile, c.Identity.Account, c.Identity.ARN, status})
+	}
+
+	return fmt.Sprintf("%s\n", t.Render())
+}
@@ -0,0 +1,43 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCredentialsTable is a test function for CredentialsTable.
+func TestCredentialsTable(t *testing.T) {
+	// save the original function, defer the restore and mock the function
+	oldBold := Bold
+	defer func() { Bold = oldBold }()
+	Bold = func(s string) string { return s }
+
+	credentials := []Credentials{
+		{Profile: "dev", Identity: Identity{Account: "123", ARN: "arn:aws:iam::123:user/dev"}},
+		{Profile: "prod", Err: errors.New("token expired")},
+	}
+
+	if got := CredentialsTable(credentials); got != credentialsTable {
+		t.Errorf("CredentialsTable()\n%s\nwant\n%s", got, credentialsTable)
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// csvComma is the field delimiter of the CSV output format.
+	csvComma = ','
+	// tsvComma is the field delimiter of the TSV output format.
+	tsvComma = '\t'
+)
+
+// outputHeaders is a map of the output formats printing a header once, to the functions returning the header.
+//
+// The header is printed by PrintResults before the first rows, and again only if it changes,
+// e.g. when the results of several resource types are printed.
+var outputHeaders = map[string]func(Results, bool) string{
+	CSV: csvHeader,
+	TSV: tsvHeader,
+}
+
+// HeaderOutput returns if the given output format prints a header once, see outputHeaders.
+func HeaderOutput(o string) bool {
+	_, ok := outputHeaders[o]
+	return ok
+}
+
+// toCSV returns the rows of the results in CSV format, without the header.
+//
+// showEmpty is ignored for csv format, as empty results have no rows.
+// showTags indicates if the tags should be shown.
+func toCSV(r Results, showEmpty, showTags bool) string {
+	_ = showEmpty // ignored for csv format
+	return delimitedRows(r, csvComma, showTags)
+}
+
+// toTSV returns the rows of the results in TSV format, without the header.
+//
+// showEmpty is ignored for tsv format, as empty results have no rows.
+// showTags indicates if the tags should be shown.
+func toTSV(r Results, showEmpty, showTags bool) string {
+	_ = showEmpty // ignored for tsv format
+	return delimitedRows(r, tsvComma, showTags)
+}
+
+// csvHeader returns the header of the results in CSV format.
+func csvHeader(r Results, showTags bool) string {
+	return delimitedHeader(r, csvComma, showTags)
+}
+
+// tsvHeader returns the header of the results in TSV format.
+func tsvHeader(r Results, showTags bool) string {
+	return delimitedHeader(r, tsvComma, showTags)
+}
+
+// delimitedHeader returns the header of the results, with the fields separated by comma.
+//
+// The header starts with the Type (if set), Profile and Region columns, followed by the flattened row headers.
+// It returns an empty string if there are no rows.
+func delimitedHeader(r Results, comma rune, showTags bool) string {
+	rows := r.GetRows()
+	if len(rows) == 0 {
+		return ""
+	}
+
+	record := []string{"Profile", "Region"}
+	if r.GetType() != "" {
+		record = append([]string{"Type"}, record...)
+	}
+	headers, _ := flattenRow(r, rows[0], showTags)
+
+	return writeRecords(comma, [][]string{append(record, headers...)})
+}
+
+// delimitedRows returns the rows of the results, with the fields separated by comma.
+//
+// Each row starts with the Type (if set), Profile and Region of the results.
+// It returns an empty string if there are no rows.
+func delimitedRows(r Results, comma rune, showTags bool) string {
+	records := [][]string{}
+	for _, row := range r.GetRows() {
+		record := []string{r.GetProfile(), r.GetRegion()}
+		if r.GetType() != "" {
+			record = append([]string{r.GetType()}, record...)
+		}
+		_, values := flattenRow(r, row, showTags)
+		records = append(records, append(record, values...))
+	}
+	if len(records) == 0 {
+		return ""
+	}
+
+	return writeRecords(comma, records)
+}
+
+// writeRecords returns the records written by a csv.Writer with the given comma, without the trailing new line.
+func writeRecords(comma rune, records [][]string) string {
+	var b strings.Builder
+
+	w := csv.NewWriter(&b)
+	w.Comma = comma
+	if err := w.WriteAll(records); err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// flattenRow returns the headers and the values of a row of the results.
+//
+// They are the columns selected for the results, see SetColumns, or the fields with a `header` tag.
+func flattenRow(r Results, row interface{}, showTags bool) (headers, values []string) {
+	if cols := selectedColumns(r); cols != nil {
+		return flattenColumns(row, cols)
+	}
+	return flattenStruct(row, showTags)
+}
+
+// flattenColumns returns the headers and the values of the given columns of a row.
+//
+// A nested struct column is expanded into its own columns, as in flattenStruct.
+func flattenColumns(row interface{}, cols []string) (headers, values []string) {
+	v := reflect.ValueOf(row)
+	for _, column := range cols {
+		value, ok := columnValue(v, column)
+		if ok && value.Kind() == reflect.Struct {
+			h, vs := flattenStruct(value.Interface(), true)
+			headers = append(headers, h...)
+			values = append(values, vs...)
+			continue
+		}
+		headers = append(headers, columnHeader(v, column))
+		if !ok {
+			values = append(values, "")
+			continue
+		}
+		values = append(values, flattenValue(value))
+	}
+	return headers, values
+}
+
+// flattenStruct returns the headers and the values of the struct fields with a `header` tag.
+//
+// The fields of a nested struct are expanded into their own columns, using their own `header` tag.
+// The other fields are flattened by flattenValue.
+// The Tags column is skipped unless showTags is true.
+func flattenStruct(i interface{}, showTags bool) (headers, values []string) {
+	v := reflect.ValueOf(i)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+
+		header, ok := v.Type().Field(i).Tag.Lookup("header")
+		if !ok || (header == "Tags" && !showTags) {
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			h, vs := flattenStruct(field.Interface(), showTags)
+			headers = append(headers, h...)
+			values = append(values, vs...)
+			continue
+		}
+		headers = append(headers, header)
+		values = append(values, flattenValue(field))
+	}
+	return headers, values
+}
+
+// flattenValue returns a struct field value as a string.
+//
+// A map is flattened to `key=value;key=value`, sorted by the keys.
+// A slice is flattened to `value;value`, sorted.
+func flattenValue(field reflect.Value) string {
+	switch field.Kind() {
+	case reflect.Map:
+		return sortedStringMapToPairs(field.Interface().(map[string]string))
+	case reflect.Slice:
+		s := append([]string{}, field.Interface().([]string)...)
+		sort.Strings(s)
+		return StringSliceToString(s, ";")
+	default:
+		return fmt.Sprint(field.Interface())
+	}
+}
+
+// sortedStringMapToPairs returns a string from a map.
+//
+// The results are sorted by the keys.
+// The string is presented in the format:
+// <key>=<value>;<key>=<value>
+func sortedStringMapToPairs(m map[string]string) string {
+	s := make([]string, 0, len(m))
+
+	for k, v := range m {
+		s = append(s, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	sort.Strings(s)
+
+	return StringSliceToString(s, ";")
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// Test_toCSV is a test function for toCSV.
+func Test_toCSV(t *testing.T) {
+	type args struct {
+		r        Results
+		showTags bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "csv with no tags",
+			args: args{r: &tr, showTags: false},
+			want: csvNoTags,
+		},
+		{
+			name: "csv with tags",
+			args: args{r: &tr, showTags: true},
+			want: csvTags,
+		},
+		{
+			name: "empty csv",
+			args: args{r: &trEmpty, showTags: true},
+			want: "",
+		},
+		{
+			name: "csv with type",
+			args: args{r: &testResults{Profile: "p", Region: "r", Type: "ec2", Data: []testDataRow{{StringField: "a,b"}}}},
+			want: `ec2,p,r,,,,"a,b"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toCSV(tt.args.r, true, tt.args.showTags); got != tt.want {
+				t.Errorf("toCSV()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_toTSV is a test function for toTSV.
+func Test_toTSV(t *testing.T) {
+	want := "testProfile\ttestRegion\ttestInfo1String1\ttestInfo1String2\tsliceValue1;sliceValue2\ttestString1\n" +
+		"testProfile\ttestRegion\ttestInfo2String1\ttestInfo2String2\tsliceValue3;sliceValue4\ttestString2"
+	if got := toTSV(&tr, false, false); got != want {
+		t.Errorf("toTSV()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// Test_csvHeader is a test function for csvHeader and tsvHeader.
+func Test_csvHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header func(Results, bool) string
+		r      Results
+		want   string
+	}{
+		{
+			name:   "csv header with tags",
+			header: csvHeader,
+			r:      &tr,
+			want:   "Profile,Region,Info String1,Info String2,Tags,Slice Field,String Field",
+		},
+		{
+			name:   "tsv header with tags",
+			header: tsvHeader,
+			r:      &tr,
+			want:   "Profile\tRegion\tInfo String1\tInfo String2\tTags\tSlice Field\tString Field",
+		},
+		{
+			name:   "empty results",
+			header: csvHeader,
+			r:      &trEmpty,
+			want:   "",
+		},
+		{
+			name:   "csv header with type",
+			header: csvHeader,
+			r:      &testResults{Type: "ec2", Data: []testDataRow{{}}},
+			want:   "Type,Profile,Region,Info String1,Info String2,Tags,Slice Field,String Field",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.header(tt.r, true); got != tt.want {
+				t.Errorf("header()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPrintResults_header tests that PrintResults prints the header once and the errors to errWriter.
+func TestPrintResults_header(t *testing.T) {
+	// save the original writer, defer the restore and mock the writer
+	oldErrWriter := errWriter
+	defer func() { errWriter = oldErrWriter }()
+	errBuffer := bytes.Buffer{}
+	errWriter = &errBuffer
+
+	resultsChan := make(chan Results, 3)
+	resultsChan <- &tr
+	resultsChan <- &trEmpty
+	resultsChan <- &testResults{Profile: "p", Region: "r", Data: []testDataRow{{StringField: "s"}}}
+	close(resultsChan)
+	done := make(chan bool, 1)
+
+	w := bytes.Buffer{}
+	PrintResults(&w, resultsChan, done, CSV, false, false)
+
+	want := "Profile,Region,Info String1,Info String2,Slice Field,String Field\n" + csvNoTags + "\np,r,,,,s\n"
+	if got := w.String(); got != want {
+		t.Errorf("PrintResults()\n%#v\nwant\n%#v", got, want)
+	}
+	wantErr := "[testProfile] [testRegion] testError1\n[testProfile] [testRegion] testError2\n"
+	if got := errBuffer.String(); got != wantErr {
+		t.Errorf("PrintResults() errors\n%#v\nwant\n%#v", got, wantErr)
+	}
+}
+
+// Test_flattenStruct is a test function for flattenStruct.
+func Test_flattenStruct(t *testing.T) {
+	wantHeaders := []string{"Info String1", "Info String2", "Tags", "Slice Field", "String Field"}
+	wantValues := []string{"testInfo1String1", "testInfo1String2", "key1=value1;key2=value2", "sliceValue1;sliceValue2",
+		"testString1"}
+	headers, values := flattenStruct(tdr1, true)
+	if !reflect.DeepEqual(headers, wantHeaders) {
+		t.Errorf("flattenStruct() headers\n%#v\nwant\n%#v", headers, wantHeaders)
+	}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Errorf("flattenStruct() values\n%#v\nwant\n%#v", values, wantValues)
+	}
+}
+
+// Test_sortedStringMapToPairs is a test function for sortedStringMapToPairs.
+func Test_sortedStringMapToPairs(t *testing.T) {
+	want := "a=1;b=2"
+	if got := sortedStringMapToPairs(map[string]string{"b": "2", "a": "1"}); got != want {
+		t.Errorf("sortedStringMapToPairs()\n%#v\nwant\n%#v", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Metadata describes a search in the json-document output.
+type Metadata struct {
+	// Version is the version of awss.
+	Version string `json:"version"`
+
+	// Command is the search command, e.g. ec2.
+	Command string `json:"command"`
+
+	// Types are the resource types of the multi-resource search.
+	Types []string `json:"types,omitempty"`
+
+	// Filters are the effective filters of the search.
+	Filters map[string][]string `json:"filters"`
+
+	// Profiles are the profiles queried.
+	Profiles []string `json:"profiles"`
+
+	// Regions are the regions queried.
+	Regions []string `json:"regions"`
+}
+
+// document is the top-level object of the json-document output.
+type document struct {
+	// GeneratedAt is the time the document was generated, in RFC3339 format.
+	GeneratedAt string `json:"generated_at"`
+
+	Metadata
+
+	// Results are the results of all profiles and regions.
+	Results []Results `json:"results"`
+}
+
+// metadata is the metadata of the json-document output.
+//
+// It is set by SetMetadata.
+var metadata = Metadata{}
+
+// SetMetadata sets the metadata of the search, shown in the json-document output.
+func SetMetadata(m Metadata) { //nolint:gocritic
+	metadata = m
+}
+
+// now is the function used to get the current time.
+//
+// We use this var to allow tests to mock the function.
+var now = time.Now
+
+// toJSONDocument returns the results of all profiles and regions in one JSON document, with the search metadata.
+//
+// The results are grouped by type, in the order of the given profiles and regions, see groupByType.
+// showEmpty indicates if empty results should be shown.
+// showTags indicates if the tags should be shown. It is ignored for json format.
+func toJSONDocument(results []Results, showEmpty, showTags bool) string {
+	_ = showTags // ignored for json format
+	doc := document{
+		Metadata:    metadata,
+		GeneratedAt: now().UTC().Format(time.RFC3339),
+		Results:     []Results{},
+	}
+	if doc.Filters == nil {
+		doc.Filters = map[string][]string{}
+	}
+
+	for _, group := range groupByType(results) {
+		for _, r := range group {
+			if r.Len() == 0 && !showEmpty {
+				continue
+			}
+			doc.Results = append(doc.Results, r)
+		}
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
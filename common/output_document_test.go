@@ -0,0 +1,104 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test_toJSONDocument is a test function for toJSONDocument.
+func Test_toJSONDocument(t *testing.T) {
+	// save the original metadata and function, defer the restore and mock them
+	oldMetadata := metadata
+	oldNow := now
+	defer func() {
+		metadata = oldMetadata
+		now = oldNow
+	}()
+	now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)) }
+
+	SetMetadata(Metadata{
+		Version:  "v1.0.0",
+		Command:  "ec2",
+		Filters:  map[string][]string{"tag": {"Project=foo"}},
+		Profiles: []string{"prod"},
+		Regions:  []string{"us-east-1"},
+	})
+	got := toJSONDocument([]Results{&trEmpty, &tr}, false, false)
+	if got != jsonDocument {
+		t.Errorf("toJSONDocument()\n%s\nwant\n%s", got, jsonDocument)
+	}
+
+	SetMetadata(Metadata{Command: "search", Types: []string{"ec2", "vpc"}})
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(toJSONDocument([]Results{&trEmpty}, true, false)), &doc); err != nil {
+		t.Fatalf("toJSONDocument() is not a JSON document: %v", err)
+	}
+	want := map[string]interface{}{
+		"version":      "",
+		"command":      "search",
+		"types":        []interface{}{"ec2", "vpc"},
+		"filters":      map[string]interface{}{},
+		"profiles":     nil,
+		"regions":      nil,
+		"generated_at": "2026-01-02T02:04:05Z",
+		"results": []interface{}{
+			map[string]interface{}{"profile": "testProfileEmpty", "region": "testRegionEmpty", "data": []interface{}{}},
+		},
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("toJSONDocument() with empty results\n%#v\nwant\n%#v", doc, want)
+	}
+}
+
+// Test_toJSONDocument_order tests that toJSONDocument keeps the given profile and region order.
+func Test_toJSONDocument_order(t *testing.T) {
+	// save the original metadata, defer the restore and mock it
+	oldMetadata := metadata
+	defer func() { metadata = oldMetadata }()
+
+	SetMetadata(Metadata{Command: "ec2", Profiles: []string{"prod", "dev"}, Regions: []string{"us-east-1", "eu-west-1"}})
+	results := []Results{
+		&testResults{Profile: "prod", Region: "us-east-1"},
+		&testResults{Profile: "prod", Region: "eu-west-1"},
+		&testResults{Profile: "dev", Region: "us-east-1"},
+	}
+	var ordered struct {
+		Results []struct {
+			Profile string `json:"profile"`
+			Region  string `json:"region"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(toJSONDocument(results, true, false)), &ordered); err != nil {
+		t.Fatalf("toJSONDocument() is not a JSON document: %v", err)
+	}
+	gotOrder := []string{}
+	for _, r := range ordered.Results {
+		gotOrder = append(gotOrder, r.Profile+"/"+r.Region)
+	}
+	wantOrder := []string{"prod/us-east-1", "prod/eu-west-1", "dev/us-east-1"}
+	if !reflect.DeepEqual(gotOrder, wantOrder) {
+		t.Errorf("toJSONDocument() results order\n%#v\nwant\n%#v", gotOrder, wantOrder)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// toMarkdown returns the results in markdown format.
+//
+// The title of the table is a heading, followed by the errors as a list grouped by category and the table.
+// showEmpty indicates if empty results should be shown.
+// showTags indicates if the tags should be shown.
+func toMarkdown(r Results, showEmpty, showTags bool) string {
+	if r.Len() == 0 && !showEmpty {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", strings.TrimSpace(tableTitle(r, plainText)))
+	if errors := r.GetErrors(); len(errors) > 0 {
+		for _, group := range groupErrors(errors) {
+			fmt.Fprintf(&b, "- %s\n", markdownBold(string(group[0].Category)))
+			for _, e := range group {
+				fmt.Fprintf(&b, "  - %s\n", e.Message)
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(tableWriter(r, showTags, markdownBold).RenderMarkdown())
+
+	return fmt.Sprintf("%s\n", b.String())
+}
+
+// toHTML returns the results in HTML format.
+//
+// The title of the table is a heading, followed by the errors as a list grouped by category and the table.
+// showEmpty indicates if empty results should be shown.
+// showTags indicates if the tags should be shown.
+func toHTML(r Results, showEmpty, showTags bool) string {
+	if r.Len() == 0 && !showEmpty {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<h3>%s</h3>\n", html.EscapeString(strings.TrimSpace(tableTitle(r, plainText))))
+	if errors := r.GetErrors(); len(errors) > 0 {
+		b.WriteString("<ul>\n")
+		for _, group := range groupErrors(errors) {
+			fmt.Fprintf(&b, "  <li>%s\n    <ul>\n", html.EscapeString(string(group[0].Category)))
+			for _, e := range group {
+				fmt.Fprintf(&b, "      <li>%s</li>\n", html.EscapeString(e.Message))
+			}
+			b.WriteString("    </ul>\n  </li>\n")
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString(tableWriter(r, showTags, plainText).RenderHTML())
+
+	return fmt.Sprintf("%s\n", b.String())
+}
+
+// markdownBold returns a string in markdown bold.
+func markdownBold(s string) string {
+	if s == "" {
+		return ""
+	}
+	return fmt.Sprintf("**%s**", s)
+}
+
+// plainText returns the string as is. It is used instead of Bold where the ANSI escapes cannot be shown.
+func plainText(s string) string {
+	return s
+}
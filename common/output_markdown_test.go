@@ -0,0 +1,87 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test_toMarkdown is a test function for toMarkdown.
+func Test_toMarkdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		r         Results
+		showEmpty bool
+		want      string
+	}{
+		{name: "heading, errors and table", r: &tr, want: markdownNoTags},
+		{name: "empty results showEmpty false", r: &trEmpty, want: ""},
+		{
+			name:      "empty results with type",
+			r:         &testResults{Type: "ec2", Profile: "p", Region: "r"},
+			showEmpty: true,
+			want: "### [Type] ec2 [Profile] p [Region] r [Sort] field\n\n" +
+				"| Struct Field | Slice Field | String Field |\n| ---:| ---:| ---:|\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toMarkdown(tt.r, tt.showEmpty, false); got != tt.want {
+				t.Errorf("toMarkdown()\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_toHTML is a test function for toHTML.
+func Test_toHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      Results
+		want   string
+		prefix bool
+	}{
+		{name: "heading, errors and table with tags", r: &tr, want: htmlTags},
+		{name: "empty results showEmpty false", r: &trEmpty, want: ""},
+		{
+			name: "errors are escaped",
+			r: &testResults{
+				Profile: "p", Region: "r", Data: []testDataRow{{StringField: "a&b"}},
+				Errors: []SearchError{{Category: CategoryOther, Message: "<error>"}},
+			},
+			want: "<h3>[Profile] p [Region] r [Sort] field</h3>\n<ul>\n  <li>other\n    <ul>\n" +
+				"      <li>&lt;error&gt;</li>\n    </ul>\n  </li>\n</ul>\n",
+			prefix: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toHTML(tt.r, false, true)
+			if tt.prefix && strings.HasPrefix(got, tt.want) {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("toHTML()\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
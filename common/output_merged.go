@@ -0,0 +1,209 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// mergedOutputs is a map of output formats to functions that print all the results at once in the given format.
+//
+// The key is the output format.
+// The value is the function that prints the results in the given format.
+var mergedOutputs = map[string]func([]Results, bool, bool) string{
+	TableMerged:  toTableMerged,
+	JSONDocument: toJSONDocument,
+}
+
+// accountID is the function used to get the account ID of a profile in the merged outputs.
+//
+// The results are printed even when the searches are cancelled, so the account is looked up with its own context.
+// We use this var to allow tests to mock the function.
+var accountID = func(profile, region string) (string, error) {
+	return AccountID(context.Background(), profile, region)
+}
+
+// mergedRow is a row of the merged outputs, with the profile, the account and the region of its results.
+type mergedRow struct {
+	profile string
+	account string
+	region  string
+	row     interface{}
+}
+
+// toTableMerged returns the results of all profiles and regions in one table.
+//
+// The results of each type, in the multi-resource search, are merged in their own table.
+// The rows are sorted by the sort field of the results, across all profiles and regions.
+// showEmpty indicates if the table should be shown without rows.
+// showTags indicates if the tags should be shown.
+func toTableMerged(results []Results, showEmpty, showTags bool) string {
+	tables := []string{}
+	for _, group := range groupByType(results) {
+		if s := mergedTable(group, showEmpty, showTags); s != "" {
+			tables = append(tables, s)
+		}
+	}
+	return StringSliceToString(tables, "\n")
+}
+
+// groupByType returns the results grouped by type, in the order the types are found.
+//
+// Each group keeps the order of the results, i.e. the order of the profiles and regions given to the search.
+func groupByType(results []Results) [][]Results {
+	groups := [][]Results{}
+	index := map[string]int{}
+	for _, r := range results {
+		i, ok := index[r.GetType()]
+		if !ok {
+			i = len(groups)
+			index[r.GetType()] = i
+			groups = append(groups, []Results{})
+		}
+		groups[i] = append(groups[i], r)
+	}
+	return groups
+}
+
+// mergedTable returns the results, all of the same type, in one table with Profile, Account and Region columns.
+//
+// The errors of the results are shown in the title, grouped by category and prefixed by their profile and region.
+func mergedTable(results []Results, showEmpty, showTags bool) string {
+	rows := []mergedRow{}
+	errors := []SearchError{}
+	accountErrors := map[string]bool{}
+
+	for _, r := range results {
+		account, err := accountID(r.GetProfile(), r.GetRegion())
+		if err != nil && !accountErrors[r.GetProfile()] {
+			accountErrors[r.GetProfile()] = true
+			errors = append(errors, NewSearchError(fmt.Errorf("[%s] error getting account: %w", r.GetProfile(), err)))
+		}
+		for _, e := range r.GetErrors() {
+			e.Message = fmt.Sprintf("[%s] [%s] %s", r.GetProfile(), r.GetRegion(), e.Message)
+			errors = append(errors, e)
+		}
+		for _, row := range r.GetRows() {
+			rows = append(rows, mergedRow{profile: r.GetProfile(), account: account, region: r.GetRegion(), row: row})
+		}
+	}
+	if len(rows) == 0 && !showEmpty {
+		return ""
+	}
+
+	sortField := results[0].GetSortField()
+	sortMergedRows(rows, sortField)
+
+	t := newTableWriter()
+	t.SetTitle(mergedTitle(results[0].GetType(), sortField, errors))
+
+	if cols := selectedColumns(results[0]); cols != nil {
+		t.AppendHeader(append(table.Row{"Profile", "Account", "Region"}, headerFromColumns(results[0], cols)...))
+		for _, r := range rows {
+			t.AppendRow(append(table.Row{r.profile, r.account, r.region}, rowFromColumns(r.row, cols, Bold)...))
+		}
+		return fmt.Sprintf("%s\n", t.Render())
+	}
+
+	t.AppendHeader(append(table.Row{"Profile", "Account", "Region"}, results[0].GetHeaders()...))
+	for _, r := range rows {
+		t.AppendRow(append(table.Row{r.profile, r.account, r.region}, rowFromStruct(r.row, Bold)...))
+	}
+	t.SetColumnConfigs(
+		[]table.ColumnConfig{
+			{Name: "Tags", Hidden: !showTags},
+		},
+	)
+
+	return fmt.Sprintf("%s\n", t.Render())
+}
+
+// mergedTitle returns the title of a merged table, with the type, the sort field and the errors.
+func mergedTitle(resultsType, sortField string, errors []SearchError) string {
+	title := ""
+	if resultsType != "" {
+		title = fmt.Sprintf("%s %s ", Bold("[Type]"), resultsType)
+	}
+	if sortField != "" {
+		title += fmt.Sprintf("%s %s", Bold("[Sort]"), sortField)
+	}
+	if len(errors) > 0 {
+		title += fmt.Sprintf("\n\n%s", errorsToString(errors, Bold))
+	}
+	return title
+}
+
+// sortMergedRows sorts the rows by the field with the given `sort` tag, keeping the order of equal rows.
+//
+// The rows are not sorted if no field has the sort tag.
+func sortMergedRows(rows []mergedRow, field string) {
+	if field == "" || len(rows) == 0 {
+		return
+	}
+	if _, ok := sortValue(reflect.ValueOf(rows[0].row), field); !ok {
+		return
+	}
+
+	sort.SliceStable(rows, func(p, q int) bool {
+		vp, _ := sortValue(reflect.ValueOf(rows[p].row), field)
+		vq, _ := sortValue(reflect.ValueOf(rows[q].row), field)
+		return lessValue(vp, vq)
+	})
+}
+
+// sortValue returns the value of the struct field with the given `sort` tag.
+//
+// The fields of a nested struct are searched too, e.g. the ENI interface info.
+func sortValue(v reflect.Value, field string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("sort") == field {
+			return v.Field(i), true
+		}
+		if v.Field(i).Kind() != reflect.Struct {
+			continue
+		}
+		if nested, ok := sortValue(v.Field(i), field); ok {
+			return nested, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// lessValue reports whether p is less than q.
+//
+// Numbers are compared by value, e.g. the subnet utilization, the other kinds by their string representation.
+func lessValue(p, q reflect.Value) bool {
+	switch p.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return p.Int() < q.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return p.Uint() < q.Uint()
+	case reflect.Float32, reflect.Float64:
+		return p.Float() < q.Float()
+	default:
+		return fmt.Sprint(p.Interface()) < fmt.Sprint(q.Interface())
+	}
+}
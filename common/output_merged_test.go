@@ -0,0 +1,189 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Test_toTableMerged is a test function for toTableMerged.
+func Test_toTableMerged(t *testing.T) {
+	// save the original functions, defer the restore and mock the functions
+	oldBold := Bold
+	oldAccountID := accountID
+	defer func() {
+		Bold = oldBold
+		accountID = oldAccountID
+	}()
+	Bold = func(s string) string { return s }
+	accountID = func(profile, _ string) (string, error) {
+		if profile == "noAccount" {
+			return "", fmt.Errorf("no credentials")
+		}
+		return "123", nil
+	}
+
+	mergedResults := func() []Results {
+		return []Results{
+			&testResults{Profile: "prod", Region: "us-east-1", Data: []testDataRow{{StringField: "c"}, {StringField: "a"}}},
+			&testResults{Profile: "dev", Region: "eu-west-1", Data: []testDataRow{{StringField: "b"}},
+				Errors: []SearchError{{Category: CategoryThrottled, Message: "testError"}}},
+			&testResults{Profile: "noAccount", Region: "us-east-1", Data: []testDataRow{}},
+		}
+	}
+
+	type args struct {
+		results   []Results
+		showEmpty bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "merged table sorted across profiles and regions",
+			args: args{results: mergedResults()},
+			want: tableMerged,
+		},
+		{
+			name: "merged table without rows",
+			args: args{results: []Results{&trEmpty}},
+			want: "",
+		},
+		{
+			name: "merged table grouped by type",
+			args: args{results: []Results{
+				&testResults{Type: "vpc", Profile: "p", Region: "r", Data: []testDataRow{{StringField: "vpc-1"}}},
+				&testResults{Type: "ec2", Profile: "p", Region: "r", Data: []testDataRow{{StringField: "i-1"}}},
+			}},
+			want: tableMergedTypes,
+		},
+		{
+			name: "merged table in the given profile and region order",
+			args: args{results: []Results{
+				&testResults{Profile: "prod", Region: "us-east-1", Data: []testDataRow{{StringField: "a"}}},
+				&testResults{Profile: "prod", Region: "eu-west-1", Data: []testDataRow{{StringField: "a"}}},
+				&testResults{Profile: "dev", Region: "us-east-1", Data: []testDataRow{{StringField: "a"}}},
+			}},
+			want: tableMergedOrder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toTableMerged(tt.args.results, tt.args.showEmpty, false); got != tt.want {
+				t.Errorf("toTableMerged()\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPrintResults_merged tests that PrintResults prints all the results at once for the merged outputs.
+func TestPrintResults_merged(t *testing.T) {
+	// save the original function map, defer the restore and mock the function map
+	oldMergedOutputs := mergedOutputs
+	defer func() { mergedOutputs = oldMergedOutputs }()
+	mergedOutputs = map[string]func([]Results, bool, bool) string{
+		TableMerged: func(results []Results, _, _ bool) string { return fmt.Sprintf("merged %d", len(results)) },
+	}
+
+	resultsChan := make(chan Results, 2)
+	resultsChan <- &tr
+	resultsChan <- &trEmpty
+	close(resultsChan)
+	done := make(chan bool, 1)
+
+	w := bytes.Buffer{}
+	PrintResults(&w, resultsChan, done, TableMerged, false, false)
+	if got, want := w.String(), "merged 2\n"; got != want {
+		t.Errorf("PrintResults()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// Test_groupByType is a test function for groupByType.
+func Test_groupByType(t *testing.T) {
+	results := []Results{
+		&testResults{Type: "vpc", Profile: "b", Region: "r"},
+		&testResults{Type: "ec2", Profile: "a", Region: "r"},
+		&testResults{Type: "vpc", Profile: "a", Region: "r2"},
+		&testResults{Type: "vpc", Profile: "a", Region: "r1"},
+	}
+	want := [][]string{{"vpc/b/r", "vpc/a/r2", "vpc/a/r1"}, {"ec2/a/r"}}
+
+	got := [][]string{}
+	for _, group := range groupByType(results) {
+		keys := []string{}
+		for _, r := range group {
+			keys = append(keys, r.GetType()+"/"+r.GetProfile()+"/"+r.GetRegion())
+		}
+		got = append(got, keys)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupByType()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// Test_sortMergedRows is a test function for sortMergedRows.
+func Test_sortMergedRows(t *testing.T) {
+	type nested struct {
+		Size int `sort:"size"`
+	}
+	// Utilization is the float field of the subnet rows.
+	type row struct {
+		Info        nested  `header:"Info"`
+		Name        string  `sort:"name"`
+		Utilization float64 `sort:"utilization"`
+	}
+	newRows := func() []mergedRow {
+		return []mergedRow{
+			{profile: "a", row: row{Info: nested{Size: 10}, Name: "b", Utilization: 10.2}},
+			{profile: "b", row: row{Info: nested{Size: 9}, Name: "a", Utilization: 9.5}},
+			{profile: "c", row: row{Info: nested{Size: 10}, Name: "a", Utilization: 100}},
+		}
+	}
+	tests := []struct {
+		name  string
+		field string
+		want  []string
+	}{
+		{name: "string field", field: "name", want: []string{"b", "c", "a"}},
+		{name: "nested int field", field: "size", want: []string{"b", "a", "c"}},
+		{name: "float field", field: "utilization", want: []string{"b", "a", "c"}},
+		{name: "unknown field", field: "invalid", want: []string{"a", "b", "c"}},
+		{name: "no field", field: "", want: []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := newRows()
+			sortMergedRows(rows, tt.field)
+			got := []string{}
+			for _, r := range rows {
+				got = append(got, r.profile)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortMergedRows()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
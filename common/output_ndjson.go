@@ -0,0 +1,107 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ndjsonContext holds the fields added to each line of the ndjson output.
+//
+// A field is left out if the row has a field with the same name, e.g. the `type` of the tagged resources.
+type ndjsonContext struct {
+	Type    string `json:"type,omitempty"`
+	Profile string `json:"profile,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Account string `json:"account,omitempty"`
+}
+
+// toNDJSON returns the rows of the results in newline delimited JSON format, one JSON object per row.
+//
+// Each object has the profile, the region and the account of the results, and the type when it is set,
+// followed by the row fields.
+// The errors getting the account are printed to errWriter.
+// showEmpty is ignored for ndjson format, as empty results have no rows.
+// showTags is ignored for ndjson format.
+func toNDJSON(r Results, showEmpty, showTags bool) string {
+	_ = showEmpty // ignored for ndjson format
+	_ = showTags  // ignored for ndjson format
+	rows := r.GetRows()
+	if len(rows) == 0 {
+		return ""
+	}
+
+	account, err := accountID(r.GetProfile(), r.GetRegion())
+	if err != nil {
+		fmt.Fprintf(errWriter, "[%s] error getting account: %v\n", r.GetProfile(), err)
+	}
+	rowContext := ndjsonContext{Type: r.GetType(), Profile: r.GetProfile(), Region: r.GetRegion(), Account: account}
+
+	lines := []string{}
+	for _, row := range rows {
+		line, err := ndjsonLine(rowContext, row)
+		if err != nil {
+			fmt.Fprintf(errWriter, "[%s] [%s] error encoding row: %v\n", r.GetProfile(), r.GetRegion(), err)
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return StringSliceToString(lines, "\n")
+}
+
+// ndjsonLine returns a row as a JSON object, starting with the context fields the row does not have.
+func ndjsonLine(rowContext ndjsonContext, row interface{}) (string, error) {
+	b, err := json.Marshal(row)
+	if err != nil {
+		return "", err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return "", err
+	}
+	for key, value := range map[string]*string{
+		"type":    &rowContext.Type,
+		"profile": &rowContext.Profile,
+		"region":  &rowContext.Region,
+		"account": &rowContext.Account,
+	} {
+		if _, ok := fields[key]; ok {
+			*value = ""
+		}
+	}
+
+	c, err := json.Marshal(rowContext)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(fields) == 0:
+		return string(c), nil
+	case string(c) == "{}":
+		return string(b), nil
+	default:
+		return string(c[:len(c)-1]) + "," + string(b[1:]), nil
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// Test_toNDJSON is a test function for toNDJSON.
+func Test_toNDJSON(t *testing.T) {
+	// save the original function and writer, defer the restore and mock them
+	oldAccountID := accountID
+	oldErrWriter := errWriter
+	defer func() {
+		accountID = oldAccountID
+		errWriter = oldErrWriter
+	}()
+	accountID = func(profile, _ string) (string, error) {
+		if profile == "noAccount" {
+			return "", fmt.Errorf("no credentials")
+		}
+		return "123456789012", nil
+	}
+
+	tests := []struct {
+		name    string
+		r       Results
+		want    string
+		wantErr string
+	}{
+		{
+			name: "one line per row",
+			r:    &tr,
+			want: ndjsonLines,
+		},
+		{
+			name: "empty results",
+			r:    &trEmpty,
+			want: "",
+		},
+		{
+			name: "type and no account",
+			r:    &testResults{Type: "ec2", Profile: "noAccount", Region: "r", Data: []testDataRow{{StringField: "s"}}},
+			want: `{"type":"ec2","profile":"noAccount","region":"r","struct_field":{"info_string1":"","info_string2":""},` +
+				`"map_field":null,"slice_field":null,"string_field":"s"}`,
+			wantErr: "[noAccount] error getting account: no credentials\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errBuffer := bytes.Buffer{}
+			errWriter = &errBuffer
+			if got := toNDJSON(tt.r, false, false); got != tt.want {
+				t.Errorf("toNDJSON()\n%s\nwant\n%s", got, tt.want)
+			}
+			if got := errBuffer.String(); got != tt.wantErr {
+				t.Errorf("toNDJSON() errors\n%#v\nwant\n%#v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_ndjsonLine is a test function for ndjsonLine.
+func Test_ndjsonLine(t *testing.T) {
+	type row struct {
+		Type    string `json:"type,omitempty"`
+		Account string `json:"account,omitempty"`
+	}
+	rowContext := ndjsonContext{Type: "tagged", Profile: "p", Region: "r", Account: "1"}
+
+	tests := []struct {
+		name string
+		row  interface{}
+		want string
+	}{
+		{
+			name: "row fields are kept",
+			row:  row{Type: "bucket", Account: "2"},
+			want: `{"profile":"p","region":"r","type":"bucket","account":"2"}`,
+		},
+		{name: "empty row", row: row{}, want: `{"type":"tagged","profile":"p","region":"r","account":"1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ndjsonLine(rowContext, tt.row)
+			if err != nil {
+				t.Fatalf("ndjsonLine() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ndjsonLine()\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// outputTemplate is the template of the template output format.
+//
+// It is set by SetTemplate.
+var outputTemplate *template.Template
+
+// SetTemplate parses the text of the template output format.
+//
+// The template is executed for each row of the results, and each execution is printed in its own line.
+// The row fields are accessed by their Go name, e.g. `{{.InstanceID}}`.
+// The functions `profile`, `region` and `type` return the context of the row,
+// and the functions `join`, `tag` and `default` help to format the fields, see templateFuncs.
+func SetTemplate(text string) error {
+	t, err := template.New(Template).Funcs(templateFuncs(nil)).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+	outputTemplate = t
+	return nil
+}
+
+// templateFuncs returns the functions of the template output format.
+//
+// The context functions return the profile, the region and the type of the results.
+//
+//	join ", " .NetworkInterfaces => joins a list of strings with the separator
+//	tag "Name" .Tags             => returns the value of a tag, or an empty string
+//	default "-" .PublicIPAddress => returns the default value if the field is empty
+func templateFuncs(r Results) template.FuncMap {
+	fromResults := func(f func(Results) string) func() string {
+		return func() string {
+			if r == nil {
+				return ""
+			}
+			return f(r)
+		}
+	}
+
+	return template.FuncMap{
+		"profile": fromResults(Results.GetProfile),
+		"region":  fromResults(Results.GetRegion),
+		"type":    fromResults(Results.GetType),
+		"join": func(sep string, s []string) string {
+			return strings.Join(s, sep)
+		},
+		"tag": func(key string, tags map[string]string) string {
+			return tags[key]
+		},
+		"default": func(def, value interface{}) interface{} {
+			if value == nil || reflect.ValueOf(value).IsZero() {
+				return def
+			}
+			return value
+		},
+	}
+}
+
+// toTemplate returns the rows of the results formatted by the template, one per line.
+//
+// The errors executing the template are printed to errWriter, and the row is skipped.
+// showEmpty is ignored for template format, as empty results have no rows.
+// showTags is ignored for template format, as the template chooses the fields.
+func toTemplate(r Results, showEmpty, showTags bool) string {
+	_ = showEmpty // ignored for template format
+	_ = showTags  // ignored for template format
+	if outputTemplate == nil {
+		fmt.Fprintln(errWriter, "no template set for the template output")
+		return ""
+	}
+
+	t, err := outputTemplate.Clone()
+	if err != nil {
+		fmt.Fprintf(errWriter, "[%s] [%s] error cloning template: %v\n", r.GetProfile(), r.GetRegion(), err)
+		return ""
+	}
+	t.Funcs(templateFuncs(r))
+
+	lines := []string{}
+	for _, row := range r.GetRows() {
+		var b strings.Builder
+		if err := t.Execute(&b, row); err != nil {
+			fmt.Fprintf(errWriter, "[%s] [%s] error executing template: %v\n", r.GetProfile(), r.GetRegion(), err)
+			continue
+		}
+		lines = append(lines, b.String())
+	}
+
+	return StringSliceToString(lines, "\n")
+}
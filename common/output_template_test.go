@@ -0,0 +1,103 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSetTemplate tests the SetTemplate function.
+func TestSetTemplate(t *testing.T) {
+	// save the original template and defer the restore
+	oldOutputTemplate := outputTemplate
+	defer func() { outputTemplate = oldOutputTemplate }()
+
+	if err := SetTemplate("{{.StringField}} {{profile}}"); err != nil {
+		t.Errorf("SetTemplate() unexpected error: %v", err)
+	}
+	for _, text := range []string{"{{.StringField", "{{unknown .StringField}}"} {
+		if err := SetTemplate(text); err == nil {
+			t.Errorf("SetTemplate(%s) expected error, got nil", text)
+		}
+	}
+}
+
+// Test_toTemplate is a test function for toTemplate.
+func Test_toTemplate(t *testing.T) {
+	// save the original template and writer, defer the restore and mock the writer
+	oldOutputTemplate := outputTemplate
+	oldErrWriter := errWriter
+	defer func() {
+		outputTemplate = oldOutputTemplate
+		errWriter = oldErrWriter
+	}()
+
+	tests := []struct {
+		name    string
+		text    string
+		r       Results
+		want    string
+		wantErr string
+	}{
+		{
+			name: "fields and context",
+			text: "{{profile}} {{region}} {{.StringField}} {{.StructField.InfoString1}}",
+			r:    &tr,
+			want: "testProfile testRegion testString1 testInfo1String1\ntestProfile testRegion testString2 testInfo2String1",
+		},
+		{
+			name: "helpers",
+			text: `{{join "," .SliceField}} {{tag "key1" .MapField | default "-"}} {{type | default "none"}}`,
+			r:    &tr,
+			want: "sliceValue1,sliceValue2 value1 none\nsliceValue3,sliceValue4 - none",
+		},
+		{
+			name: "empty results",
+			text: "{{.StringField}}",
+			r:    &trEmpty,
+			want: "",
+		},
+		{
+			name: "execution error",
+			text: "{{.Unknown}}",
+			r:    &testResults{Profile: "p", Region: "r", Data: []testDataRow{{}}},
+			want: "",
+			wantErr: "[p] [r] error executing template: template: template:1:2: executing \"template\" at <.Unknown>: " +
+				"can't evaluate field Unknown in type common.testDataRow\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errBuffer := bytes.Buffer{}
+			errWriter = &errBuffer
+			if err := SetTemplate(tt.text); err != nil {
+				t.Fatalf("SetTemplate() unexpected error: %v", err)
+			}
+			if got := toTemplate(tt.r, false, false); got != tt.want {
+				t.Errorf("toTemplate()\n%#v\nwant\n%#v", got, tt.want)
+			}
+			if got := errBuffer.String(); got != tt.wantErr {
+				t.Errorf("toTemplate() errors\n%#v\nwant\n%#v", got, tt.wantErr)
+			}
+		})
+	}
+}
@@ -46,7 +46,8 @@ func TestValidOutputs(t *testing.T) {
 			args: args{
 				o: "json",
 			},
-			want:  "json, json-pretty, table",
+			want: "csv, html, json, json-document, json-pretty, markdown, ndjson, " +
+				"table, table-merged, template, tsv, yaml, yaml-stream",
 			want1: true,
 		},
 		{
@@ -54,7 +55,8 @@ func TestValidOutputs(t *testing.T) {
 			args: args{
 				o: "invalid",
 			},
-			want:  "json, json-pretty, table",
+			want: "csv, html, json, json-document, json-pretty, markdown, ndjson, " +
+				"table, table-merged, template, tsv, yaml, yaml-stream",
 			want1: false,
 		},
 	}
@@ -285,6 +287,11 @@ func Test_toTable(t *testing.T) {
 			args: args{r: &trEmpty, showEmpty: false, showTags: false},
 			want: "",
 		},
+		{
+			name: "empty table with type",
+			args: args{r: &testResults{Profile: "p", Region: "r", Type: "ec2"}, showEmpty: true, showTags: false},
+			want: tableEmptyType,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -332,7 +339,7 @@ func Test_rowFromStruct(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := rowFromStruct(tt.args.i); !reflect.DeepEqual(got, tt.want) {
+			if got := rowFromStruct(tt.args.i, Bold); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("rowFromStruct()\n%#v\nwant\n%#v", got, tt.want)
 			}
 		})
@@ -375,7 +382,7 @@ func Test_headerStructFieldsToString(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := headerStructFieldsToString(tt.args.i); got != tt.want {
+			if got := headerStructFieldsToString(tt.args.i, Bold); got != tt.want {
 				t.Errorf("headerStructFieldsToString()\n%#v\nwant\n%#v", got, tt.want)
 			}
 		})
@@ -418,7 +425,7 @@ func Test_sortedStringMapToString(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := sortedStringMapToString(tt.args.m); got != tt.want {
+			if got := sortedStringMapToString(tt.args.m, Bold); got != tt.want {
 				t.Errorf("sortedStringMapToString()\n%#v\nwant\n%#v", got, tt.want)
 			}
 		})
@@ -31,7 +31,8 @@ import (
 type testResults struct {
 	Profile string        `json:"profile"`
 	Region  string        `json:"region"`
-	Errors  []string      `json:"errors,omitempty"`
+	Type    string        `json:"type,omitempty"`
+	Errors  []SearchError `json:"errors,omitempty"`
 	Data    []testDataRow `json:"data"`
 }
 
@@ -42,7 +43,8 @@ func (tr *testResults) Search(_ context.Context) {}
 func (tr *testResults) Len() int                 { return len(tr.Data) }
 func (tr *testResults) GetProfile() string       { return tr.Profile }
 func (tr *testResults) GetRegion() string        { return tr.Region }
-func (tr *testResults) GetErrors() []string      { return tr.Errors }
+func (tr *testResults) GetType() string          { return tr.Type }
+func (tr *testResults) GetErrors() []SearchError { return tr.Errors }
 func (tr *testResults) GetSortField() string     { return "field" }
 func (tr *testResults) GetHeaders() []interface{} {
 	headers := []interface{}{}
@@ -75,7 +77,7 @@ type testDataRow struct {
 	// MapField header is `Tags`` because there is a test case for `--show-tags` on toTable().
 	MapField    map[string]string `json:"map_field" header:"Tags"`
 	SliceField  []string          `json:"slice_field" header:"Slice Field"`
-	StringField string            `json:"string_field" header:"String Field"`
+	StringField string            `json:"string_field" header:"String Field" sort:"field"`
 }
 
 // testInfo is a struct used for testing.
@@ -90,12 +92,18 @@ type testInfo struct {
 //
 //	json:"profile" = testProfile
 //	json:"region"  = testRegion
-//	json:"errors"  = []string{"testError1", "testError2"}
+//	json:"errors"  = []SearchError{testError1, testError2}
 //	json:"data"    = []testDataRow{tdr1, tdr2}
 var tr = testResults{
 	Profile: "testProfile",
 	Region:  "testRegion",
-	Errors:  []string{"testError1", "testError2"},
+	Errors: []SearchError{
+		{
+			Category: CategoryAccessDenied, Operation: "EC2:DescribeInstances", RequestID: "testRequestID",
+			Message: "testError1",
+		},
+		{Category: CategoryThrottled, Message: "testError2"},
+	},
 	Data: []testDataRow{
 		tdr1,
 		tdr2,
@@ -106,12 +114,12 @@ var tr = testResults{
 //
 //	json:"profile" = testProfileEmpty
 //	json:"region"  = testRegionEmpty
-//	json:"errors"  = []string{}
+//	json:"errors"  = []SearchError{}
 //	json:"data"    = []testDataRow{}
 var trEmpty = testResults{
 	Profile: "testProfileEmpty",
 	Region:  "testRegionEmpty",
-	Errors:  []string{},
+	Errors:  []SearchError{},
 	Data:    []testDataRow{},
 }
 
@@ -175,7 +183,7 @@ var jsonEmptyNoPretty = `{"profile":"testProfileEmpty","region":"testRegionEmpty
 // jsonNoPretty is a json string used for testing.
 //
 //nolint:lll
-var jsonNoPretty = `{"profile":"testProfile","region":"testRegion","errors":["testError1","testError2"],"data":[{"struct_field":{"info_string1":"testInfo1String1","info_string2":"testInfo1String2"},"map_field":{"key1":"value1","key2":"value2"},"slice_field":["sliceValue1","sliceValue2"],"string_field":"testString1"},{"struct_field":{"info_string1":"testInfo2String1","info_string2":"testInfo2String2"},"map_field":{"key3":"value3","key4":"value4"},"slice_field":["sliceValue3","sliceValue4"],"string_field":"testString2"}]}`
+var jsonNoPretty = `{"profile":"testProfile","region":"testRegion","errors":[{"category":"access-denied","operation":"EC2:DescribeInstances","request_id":"testRequestID","message":"testError1"},{"category":"throttled","message":"testError2"}],"data":[{"struct_field":{"info_string1":"testInfo1String1","info_string2":"testInfo1String2"},"map_field":{"key1":"value1","key2":"value2"},"slice_field":["sliceValue1","sliceValue2"],"string_field":"testString1"},{"struct_field":{"info_string1":"testInfo2String1","info_string2":"testInfo2String2"},"map_field":{"key3":"value3","key4":"value4"},"slice_field":["sliceValue3","sliceValue4"],"string_field":"testString2"}]}`
 
 // jsonEmptyPretty is a json string used for testing.
 var jsonEmptyPretty = `{
@@ -189,8 +197,16 @@ var jsonPretty = `{
   "profile": "testProfile",
   "region": "testRegion",
   "errors": [
-    "testError1",
-    "testError2"
+    {
+      "category": "access-denied",
+      "operation": "EC2:DescribeInstances",
+      "request_id": "testRequestID",
+      "message": "testError1"
+    },
+    {
+      "category": "throttled",
+      "message": "testError2"
+    }
   ],
   "data": [
     {
@@ -230,7 +246,9 @@ var jsonPretty = `{
 var tableNoTags = `+-------------------------------------------------------------+
 | [Profile] testProfile [Region] testRegion [Sort] field      |
 |                                                             |
+| [access-denied]                                             |
 | testError1                                                  |
+| [throttled]                                                 |
 | testError2                                                  |
 +--------------------------------+-------------+--------------+
 | Struct Field                   | Slice Field | String Field |
@@ -247,7 +265,9 @@ var tableNoTags = `+------------------------------------------------------------
 var tableTags = `+----------------------------------------------------------------------------+
 | [Profile] testProfile [Region] testRegion [Sort] field                     |
 |                                                                            |
+| [access-denied]                                                            |
 | testError1                                                                 |
+| [throttled]                                                                |
 | testError2                                                                 |
 +--------------------------------+--------------+-------------+--------------+
 | Struct Field                   | Tags         | Slice Field | String Field |
@@ -279,3 +299,292 @@ var tableEmptyTags = `+--------------------------------------------------+
 +--------------+------+-------------+--------------+
 +--------------+------+-------------+--------------+
 `
+
+// tableEmptyType is a test table output from an empty testResults with a type.
+var tableEmptyType = `+-------------------------------------------+
+| [Type] ec2 [Profile] p [Region] r [Sort]  |
+| field                                     |
++--------------+-------------+--------------+
+| Struct Field | Slice Field | String Field |
++--------------+-------------+--------------+
++--------------+-------------+--------------+
+`
+
+// csvNoTags is a test csv output from tr.
+var csvNoTags = `testProfile,testRegion,testInfo1String1,testInfo1String2,sliceValue1;sliceValue2,testString1
+testProfile,testRegion,testInfo2String1,testInfo2String2,sliceValue3;sliceValue4,testString2`
+
+// csvTags is a test csv output from tr with tags.
+//
+//nolint:lll
+var csvTags = `testProfile,testRegion,testInfo1String1,testInfo1String2,key1=value1;key2=value2,sliceValue1;sliceValue2,testString1
+testProfile,testRegion,testInfo2String1,testInfo2String2,key3=value3;key4=value4,sliceValue3;sliceValue4,testString2`
+
+// yamlItem is a test yaml output from tr.
+var yamlItem = `- profile: testProfile
+  region: testRegion
+  errors:
+    - category: access-denied
+      operation: EC2:DescribeInstances
+      request_id: testRequestID
+      message: testError1
+    - category: throttled
+      message: testError2
+  data:
+    - struct_field:
+        info_string1: testInfo1String1
+        info_string2: testInfo1String2
+      map_field:
+        key1: value1
+        key2: value2
+      slice_field:
+        - sliceValue1
+        - sliceValue2
+      string_field: testString1
+    - struct_field:
+        info_string1: testInfo2String1
+        info_string2: testInfo2String2
+      map_field:
+        key3: value3
+        key4: value4
+      slice_field:
+        - sliceValue3
+        - sliceValue4
+      string_field: testString2`
+
+// yamlDocument is a test yaml-stream output from tr.
+var yamlDocument = `---
+profile: testProfile
+region: testRegion
+errors:
+  - category: access-denied
+    operation: EC2:DescribeInstances
+    request_id: testRequestID
+    message: testError1
+  - category: throttled
+    message: testError2
+data:
+  - struct_field:
+      info_string1: testInfo1String1
+      info_string2: testInfo1String2
+    map_field:
+      key1: value1
+      key2: value2
+    slice_field:
+      - sliceValue1
+      - sliceValue2
+    string_field: testString1
+  - struct_field:
+      info_string1: testInfo2String1
+      info_string2: testInfo2String2
+    map_field:
+      key3: value3
+      key4: value4
+    slice_field:
+      - sliceValue3
+      - sliceValue4
+    string_field: testString2`
+
+// tableMerged is a test table-merged output.
+var tableMerged = `+---------------------------------------------------------------------------+
+| [Sort] field                                                              |
+|                                                                           |
+| [throttled]                                                               |
+| [dev] [eu-west-1] testError                                               |
+| [other]                                                                   |
+| [noAccount] error getting account: no credentials                         |
++---------+---------+-----------+--------------+-------------+--------------+
+| Profile | Account | Region    | Struct Field | Slice Field | String Field |
++---------+---------+-----------+--------------+-------------+--------------+
+| prod    | 123     | us-east-1 |              |             | a            |
++---------+---------+-----------+--------------+-------------+--------------+
+| dev     | 123     | eu-west-1 |              |             | b            |
++---------+---------+-----------+--------------+-------------+--------------+
+| prod    | 123     | us-east-1 |              |             | c            |
++---------+---------+-----------+--------------+-------------+--------------+
+`
+
+// tableMergedTypes is a test table-merged output of several types.
+var tableMergedTypes = `+------------------------------------------------------------------------+
+| [Type] vpc [Sort] field                                                |
++---------+---------+--------+--------------+-------------+--------------+
+| Profile | Account | Region | Struct Field | Slice Field | String Field |
++---------+---------+--------+--------------+-------------+--------------+
+| p       | 123     | r      |              |             | vpc-1        |
++---------+---------+--------+--------------+-------------+--------------+
+
++------------------------------------------------------------------------+
+| [Type] ec2 [Sort] field                                                |
++---------+---------+--------+--------------+-------------+--------------+
+| Profile | Account | Region | Struct Field | Slice Field | String Field |
++---------+---------+--------+--------------+-------------+--------------+
+| p       | 123     | r      |              |             | i-1          |
++---------+---------+--------+--------------+-------------+--------------+
+`
+
+// tableMergedOrder is a test table-merged output keeping the given profile and region order.
+var tableMergedOrder = `+---------------------------------------------------------------------------+
+| [Sort] field                                                              |
++---------+---------+-----------+--------------+-------------+--------------+
+| Profile | Account | Region    | Struct Field | Slice Field | String Field |
++---------+---------+-----------+--------------+-------------+--------------+
+| prod    | 123     | us-east-1 |              |             | a            |
++---------+---------+-----------+--------------+-------------+--------------+
+| prod    | 123     | eu-west-1 |              |             | a            |
++---------+---------+-----------+--------------+-------------+--------------+
+| dev     | 123     | us-east-1 |              |             | a            |
++---------+---------+-----------+--------------+-------------+--------------+
+`
+
+// tableColumns is a test table output with the selected columns.
+var tableColumns = `+----------------------------------+
+| [Profile] p [Region] r [Sort] fi |
+| eld                              |
++------+-------+-------------------+
+| ID   | Owner | Info              |
++------+-------+-------------------+
+| id-1 | team  | Info String1: one |
+|      |       | Info String2: two |
++------+-------+-------------------+
+`
+
+// jsonDocument is a test json-document output from trEmpty and tr.
+var jsonDocument = `{
+  "generated_at": "2026-01-02T02:04:05Z",
+  "version": "v1.0.0",
+  "command": "ec2",
+  "filters": {
+    "tag": [
+      "Project=foo"
+    ]
+  },
+  "profiles": [
+    "prod"
+  ],
+  "regions": [
+    "us-east-1"
+  ],
+  "results": [
+    {
+      "profile": "testProfile",
+      "region": "testRegion",
+      "errors": [
+        {
+          "category": "access-denied",
+          "operation": "EC2:DescribeInstances",
+          "request_id": "testRequestID",
+          "message": "testError1"
+        },
+        {
+          "category": "throttled",
+          "message": "testError2"
+        }
+      ],
+      "data": [
+        {
+          "struct_field": {
+            "info_string1": "testInfo1String1",
+            "info_string2": "testInfo1String2"
+          },
+          "map_field": {
+            "key1": "value1",
+            "key2": "value2"
+          },
+          "slice_field": [
+            "sliceValue1",
+            "sliceValue2"
+          ],
+          "string_field": "testString1"
+        },
+        {
+          "struct_field": {
+            "info_string1": "testInfo2String1",
+            "info_string2": "testInfo2String2"
+          },
+          "map_field": {
+            "key3": "value3",
+            "key4": "value4"
+          },
+          "slice_field": [
+            "sliceValue3",
+            "sliceValue4"
+          ],
+          "string_field": "testString2"
+        }
+      ]
+    }
+  ]
+}`
+
+// ndjsonLines is a test ndjson output from tr.
+//
+//nolint:lll
+var ndjsonLines = `{"profile":"testProfile","region":"testRegion","account":"123456789012","struct_field":{"info_string1":"testInfo1String1","info_string2":"testInfo1String2"},"map_field":{"key1":"value1","key2":"value2"},"slice_field":["sliceValue1","sliceValue2"],"string_field":"testString1"}
+{"profile":"testProfile","region":"testRegion","account":"123456789012","struct_field":{"info_string1":"testInfo2String1","info_string2":"testInfo2String2"},"map_field":{"key3":"value3","key4":"value4"},"slice_field":["sliceValue3","sliceValue4"],"string_field":"testString2"}`
+
+// markdownNoTags is a test markdown output from tr without tags.
+//
+//nolint:lll
+var markdownNoTags = `### [Profile] testProfile [Region] testRegion [Sort] field
+
+- **access-denied**
+  - testError1
+- **throttled**
+  - testError2
+
+| Struct Field | Slice Field | String Field |
+| --- | --- | --- |
+| **Info String1**: testInfo1String1<br/>**Info String2**: testInfo1String2 | sliceValue1<br/>sliceValue2 | testString1 |
+| **Info String1**: testInfo2String1<br/>**Info String2**: testInfo2String2 | sliceValue3<br/>sliceValue4 | testString2 |
+`
+
+// htmlTags is a test HTML output from tr with tags.
+var htmlTags = `<h3>[Profile] testProfile [Region] testRegion [Sort] field</h3>
+<ul>
+  <li>access-denied
+    <ul>
+      <li>testError1</li>
+    </ul>
+  </li>
+  <li>throttled
+    <ul>
+      <li>testError2</li>
+    </ul>
+  </li>
+</ul>
+<table class="go-pretty-table">
+  <thead>
+  <tr>
+    <th>Struct Field</th>
+    <th>Tags</th>
+    <th>Slice Field</th>
+    <th>String Field</th>
+  </tr>
+  </thead>
+  <tbody>
+  <tr>
+    <td>Info String1: testInfo1String1<br/>Info String2: testInfo1String2</td>
+    <td>key1: value1<br/>key2: value2</td>
+    <td>sliceValue1<br/>sliceValue2</td>
+    <td>testString1</td>
+  </tr>
+  <tr>
+    <td>Info String1: testInfo2String1<br/>Info String2: testInfo2String2</td>
+    <td>key3: value3<br/>key4: value4</td>
+    <td>sliceValue3<br/>sliceValue4</td>
+    <td>testString2</td>
+  </tr>
+  </tbody>
+</table>
+`
+
+// credentialsTable is a test credential status table output.
+var credentialsTable = `+-----------------------------------------------------------------------+
+| [Credentials]                                                         |
++---------+---------+---------------------------+-----------------------+
+| Profile | Account | ARN                       | Status                |
++---------+---------+---------------------------+-----------------------+
+| dev     | 123     | arn:aws:iam::123:user/dev | ok                    |
+| prod    |         |                           | [other] token expired |
++---------+---------+---------------------------+-----------------------+
+`
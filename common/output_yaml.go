@@ -0,0 +1,118 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// yamlIndent is the indentation of the YAML output formats.
+const yamlIndent = 2
+
+// toYAML returns the results as an item of a YAML sequence.
+//
+// The items of the results printed one after the other make a single YAML document.
+// showEmpty indicates if empty results should be shown.
+// showTags indicates if the tags should be shown. It is ignored for yaml format.
+func toYAML(r Results, showEmpty, showTags bool) string {
+	_ = showTags // ignored for yaml format
+	if r.Len() == 0 && !showEmpty {
+		return ""
+	}
+	node, err := yamlNode(r)
+	if err != nil {
+		return ""
+	}
+	s, err := marshalYAML(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{node}})
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// toYAMLStream returns the results as a YAML document, starting with the `---` separator.
+//
+// The documents of the results printed one after the other make a multi-document YAML stream.
+// showEmpty indicates if empty results should be shown.
+// showTags indicates if the tags should be shown. It is ignored for yaml format.
+func toYAMLStream(r Results, showEmpty, showTags bool) string {
+	_ = showTags // ignored for yaml format
+	if r.Len() == 0 && !showEmpty {
+		return ""
+	}
+	node, err := yamlNode(r)
+	if err != nil {
+		return ""
+	}
+	s, err := marshalYAML(node)
+	if err != nil {
+		return ""
+	}
+	return "---\n" + s
+}
+
+// yamlNode returns the YAML node of a value.
+//
+// The value is encoded to JSON first, so the field names and their order follow the json struct tags.
+func yamlNode(v interface{}) (*yaml.Node, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	node := doc.Content[0]
+	resetYAMLStyle(node)
+
+	return node, nil
+}
+
+// resetYAMLStyle resets the style of the node and its children to the YAML block style.
+//
+// A node decoded from JSON has the flow style and quoted strings.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// marshalYAML returns the YAML encoding of the node, without the trailing new line.
+func marshalYAML(node *yaml.Node) (string, error) {
+	var b strings.Builder
+
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(yamlIndent)
+	if err := enc.Encode(node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains common functions and types.
+//
+// It has AWS related functions and types.
+// It also has functions to print the results in different formats.
+package common
+
+import (
+	"testing"
+)
+
+// Test_toYAML is a test function for toYAML.
+func Test_toYAML(t *testing.T) {
+	type args struct {
+		r         Results
+		showEmpty bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "yaml",
+			args: args{r: &tr, showEmpty: false},
+			want: yamlItem,
+		},
+		{
+			name: "empty yaml",
+			args: args{r: &trEmpty, showEmpty: true},
+			want: "- profile: testProfileEmpty\n  region: testRegionEmpty\n  data: []",
+		},
+		{
+			name: "empty yaml showEmpty false",
+			args: args{r: &trEmpty, showEmpty: false},
+			want: "",
+		},
+		{
+			name: "yaml keeps the string type",
+			args: args{r: &testResults{Profile: "123", Region: "true", Data: []testDataRow{}}, showEmpty: true},
+			want: "- profile: \"123\"\n  region: \"true\"\n  data: []",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toYAML(tt.args.r, tt.args.showEmpty, false); got != tt.want {
+				t.Errorf("toYAML()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_toYAMLStream is a test function for toYAMLStream.
+func Test_toYAMLStream(t *testing.T) {
+	type args struct {
+		r         Results
+		showEmpty bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "yaml stream",
+			args: args{r: &tr, showEmpty: false},
+			want: yamlDocument,
+		},
+		{
+			name: "empty yaml stream",
+			args: args{r: &trEmpty, showEmpty: true},
+			want: "---\nprofile: testProfileEmpty\nregion: testRegionEmpty\ndata: []",
+		},
+		{
+			name: "empty yaml stream showEmpty false",
+			args: args{r: &trEmpty, showEmpty: false},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toYAMLStream(tt.args.r, tt.args.showEmpty, false); got != tt.want {
+				t.Errorf("toYAMLStream()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,8 +24,11 @@ type BaseResults struct {
 	// Region is the region used to search.
 	Region string `json:"region"`
 
+	// Type is the resource type searched. It is only set by the multi-resource search.
+	Type string `json:"type,omitempty"`
+
 	// Errors contains the errors found during the search.
-	Errors []string `json:"errors,omitempty"`
+	Errors []SearchError `json:"errors,omitempty"`
 
 	// SortField is the field used to sort the results.
 	SortField string `json:"-"`
@@ -37,8 +40,17 @@ func (b *BaseResults) GetProfile() string { return b.Profile }
 // GetRegion returns the region used to search.
 func (b *BaseResults) GetRegion() string { return b.Region }
 
+// GetType returns the resource type searched, or an empty string.
+func (b *BaseResults) GetType() string { return b.Type }
+
+// SetType sets the resource type searched.
+func (b *BaseResults) SetType(t string) { b.Type = t }
+
+// AddError adds an error to the errors found during the search, see NewSearchError.
+func (b *BaseResults) AddError(err error) { b.Errors = append(b.Errors, NewSearchError(err)) }
+
 // GetErrors returns the errors found during the search.
-func (b *BaseResults) GetErrors() []string { return b.Errors }
+func (b *BaseResults) GetErrors() []SearchError { return b.Errors }
 
 // GetSortField returns the field used to sort the results.
 func (b *BaseResults) GetSortField() string { return b.SortField }
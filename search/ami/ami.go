@@ -0,0 +1,329 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ami contains the search for AMIs.
+//
+// It implements the common.Results interface.
+package ami
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/dyegoe/awss/common"
+	searchEC2 "github.com/dyegoe/awss/search/ec2"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// FilterUsed is the filter key used to keep only the AMIs used, or not used, by running instances.
+//
+// The EC2 API has no filter for it, so it is applied to the results. Values are `true` and/or `false`.
+const FilterUsed = "used"
+
+// self is the value of the owner and executable users meaning the account of the credentials.
+const self = "self"
+
+// Results describes results of the AMIs search.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the AMIs found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search.
+	Filters map[string][]string `json:"-"`
+
+	// used holds the accepted states parsed from the "used" filter.
+	// It is empty when the filter is not used.
+	used []bool
+}
+
+// dataRow represents a row of the AMIs search results.
+type dataRow struct {
+	// ImageID is the ID of the AMI.
+	ImageID string `json:"id,omitempty" header:"ID" sort:"id"`
+
+	// Name is the name of the AMI.
+	Name string `json:"name,omitempty" header:"Name" sort:"name"`
+
+	// OwnerID is the ID of the account that owns the AMI.
+	OwnerID string `json:"owner_id,omitempty" header:"Owner" sort:"owner"`
+
+	// State is the state of the AMI.
+	State string `json:"state,omitempty" header:"State" sort:"state"`
+
+	// CreationDate is the date and time the AMI was created.
+	CreationDate string `json:"creation_date,omitempty" header:"Created" sort:"creation-date"`
+
+	// DeprecationTime is the date and time the AMI is deprecated, if set.
+	DeprecationTime string `json:"deprecation_time,omitempty" header:"Deprecated" sort:"deprecation-time"`
+
+	// Architecture is the architecture of the AMI.
+	Architecture string `json:"architecture,omitempty" header:"Architecture" sort:"architecture"`
+
+	// RootDeviceType is the type of the root device, ebs or instance-store.
+	RootDeviceType string `json:"root_device_type,omitempty" header:"Root Device" sort:"root-device-type"`
+
+	// Public indicates whether the AMI has public launch permissions.
+	Public string `json:"public,omitempty" header:"Public" sort:"public"`
+
+	// UsedBy is the number of running instances launched from the AMI, in the same account and region.
+	UsedBy int `json:"used_by" header:"Used By" sort:"used-by"`
+
+	// Tags are the tags assigned to the AMI.
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// New initiates and returns a new instance of AMIs results.
+func New(profile, region string, filters map[string][]string, sortField string) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:    []dataRow{},
+		Filters: filters,
+	}
+}
+
+// Search performs the AMIs search.
+//
+// Results are stored in the Data field.
+func (r *Results) Search(ctx context.Context) {
+	input, err := r.getFilters()
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+	client := ec2.NewFromConfig(cfg)
+
+	seen := map[string]bool{}
+	for _, in := range imageQueries(input) {
+		paginator := ec2.NewDescribeImagesPaginator(client, in)
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				r.AddError(fmt.Errorf("error describing images: %w", err))
+				return
+			}
+			for _, image := range page.Images { //nolint:gocritic
+				row := parseImage(&image)
+				if seen[row.ImageID] {
+					continue
+				}
+				seen[row.ImageID] = true
+				r.Data = append(r.Data, row)
+			}
+		}
+	}
+
+	r.countUsage(ctx)
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// imageQueries returns the DescribeImages inputs to run.
+//
+// Without owners, executable users or image IDs, the AMIs owned by the account and the AMIs shared
+// with it are searched in two queries. Otherwise, the input is used as-is.
+func imageQueries(input *ec2.DescribeImagesInput) []*ec2.DescribeImagesInput {
+	if len(input.Owners) > 0 || len(input.ExecutableUsers) > 0 || len(input.ImageIds) > 0 {
+		return []*ec2.DescribeImagesInput{input}
+	}
+	owned, shared := *input, *input
+	owned.Owners = []string{self}
+	shared.ExecutableUsers = []string{self}
+	return []*ec2.DescribeImagesInput{&owned, &shared}
+}
+
+// parseImage converts a single Image into a dataRow.
+func parseImage(image *types.Image) dataRow {
+	row := dataRow{
+		ImageID:         common.StringValue(image.ImageId),
+		Name:            common.StringValue(image.Name),
+		OwnerID:         common.StringValue(image.OwnerId),
+		State:           string(image.State),
+		CreationDate:    common.StringValue(image.CreationDate),
+		DeprecationTime: common.StringValue(image.DeprecationTime),
+		Architecture:    string(image.Architecture),
+		RootDeviceType:  string(image.RootDeviceType),
+		Tags:            common.TagsToMap(image.Tags),
+	}
+	if image.Public != nil {
+		row.Public = strconv.FormatBool(*image.Public)
+	}
+	return row
+}
+
+// countUsage sets the number of running instances launched from each AMI, then applies the "used" filter.
+func (r *Results) countUsage(ctx context.Context) {
+	if len(r.Data) == 0 {
+		return
+	}
+	imageIDs := make([]string, 0, len(r.Data))
+	for i := range r.Data {
+		imageIDs = append(imageIDs, r.Data[i].ImageID)
+	}
+
+	usage, err := searchEC2.SearchImageUsage(ctx, r.Profile, r.Region, imageIDs)
+	if err != nil {
+		r.AddError(err)
+		return
+	}
+	r.applyUsage(usage)
+}
+
+// applyUsage sets the UsedBy field of the rows from the given usage
+// and drops the rows not matching the "used" filter.
+func (r *Results) applyUsage(usage map[string]int) {
+	data := make([]dataRow, 0, len(r.Data))
+	for i := range r.Data {
+		r.Data[i].UsedBy = usage[r.Data[i].ImageID]
+		if !common.MatchBool(r.Data[i].UsedBy > 0, r.used) {
+			continue
+		}
+		data = append(data, r.Data[i])
+	}
+	r.Data = data
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getFilters returns the filters used to search.
+//
+// The filters are defined in the results.Filters field.
+// The deprecated AMIs are always included.
+// The "used" filter is not an AWS filter. It is parsed into results.used and applied to the results.
+// Except for "image-id", "owner", "tag" and "used", all other filters are passed as-is.
+func (r *Results) getFilters() (*ec2.DescribeImagesInput, error) {
+	input := ec2.DescribeImagesInput{IncludeDeprecated: aws.Bool(true)}
+
+	for key, values := range r.Filters {
+		switch key {
+		case "image-id":
+			input.ImageIds = values
+		case "owner":
+			input.Owners = values
+		case "tag":
+			tagFilters, err := common.FilterTags(values)
+			if err != nil {
+				return nil, fmt.Errorf("building tag filters: %w", err)
+			}
+			input.Filters = append(input.Filters, tagFilters...)
+		case FilterUsed:
+			used, err := common.ParseBools(key, values)
+			if err != nil {
+				return nil, err
+			}
+			r.used = used
+		default:
+			input.Filters = append(input.Filters, common.FilterDefault(key, values)...)
+		}
+	}
+	return &input, nil
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	fieldName := sortFields[field]
+	sort.Slice(r.Data, func(p, q int) bool {
+		pField := reflect.ValueOf(r.Data[p]).FieldByName(fieldName)
+		qField := reflect.ValueOf(r.Data[q]).FieldByName(fieldName)
+		if pField.Kind() == reflect.Int {
+			return pField.Int() < qField.Int()
+		}
+		return pField.String() < qField.String()
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
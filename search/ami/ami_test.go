@@ -0,0 +1,237 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ami contains the search for AMIs.
+//
+// It implements the common.Results interface.
+package ami
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{"image-id": {"ami-123"}}, "name")
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "name",
+		},
+		Data:    []dataRow{},
+		Filters: map[string][]string{"image-id": {"ami-123"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	want := []interface{}{
+		"ID", "Name", "Owner", "State", "Created", "Deprecated",
+		"Architecture", "Root Device", "Public", "Used By", "Tags",
+	}
+	if got := New("", "", nil, "").GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_getFilters tests the getFilters function.
+func TestResults_getFilters(t *testing.T) {
+	r := New("default", "us-east-1", map[string][]string{
+		"name":      {"web-*"},
+		"tag":       {"key=value"},
+		"is-public": {"true"},
+		FilterUsed:  {"false"},
+		"owner":     {"self"},
+		"image-id":  {"ami-123"},
+	}, "name")
+	got, err := r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	if got.IncludeDeprecated == nil || !*got.IncludeDeprecated {
+		t.Error("IncludeDeprecated = false, want true")
+	}
+	if !reflect.DeepEqual(got.Owners, []string{"self"}) || !reflect.DeepEqual(got.ImageIds, []string{"ami-123"}) {
+		t.Errorf("Owners = %v, ImageIds = %v, want [self], [ami-123]", got.Owners, got.ImageIds)
+	}
+	if !reflect.DeepEqual(r.used, []bool{false}) {
+		t.Errorf("used = %v, want [false]", r.used)
+	}
+	want := map[string][]string{
+		"name":      {"web-*"},
+		"tag:key":   {"value"},
+		"is-public": {"true"},
+	}
+	gotByName := make(map[string][]string, len(got.Filters))
+	for _, f := range got.Filters {
+		gotByName[*f.Name] = f.Values
+	}
+	if !reflect.DeepEqual(gotByName, want) {
+		t.Errorf("Results.getFilters()\n%#v\nwant\n%#v", gotByName, want)
+	}
+
+	r.Filters = map[string][]string{"tag": {"invalid"}}
+	if _, err := r.getFilters(); err == nil {
+		t.Error("Results.getFilters() expected error for malformed tag, got nil")
+	}
+	r.Filters = map[string][]string{FilterUsed: {"maybe"}}
+	if _, err := r.getFilters(); err == nil {
+		t.Error("Results.getFilters() expected error for invalid used value, got nil")
+	}
+}
+
+// TestImageQueries tests the imageQueries function.
+func TestImageQueries(t *testing.T) {
+	input := &ec2.DescribeImagesInput{Filters: []types.Filter{{Name: common.String("name"), Values: []string{"web-*"}}}}
+	got := imageQueries(input)
+	if len(got) != 2 {
+		t.Fatalf("imageQueries() returned %d queries, want 2", len(got))
+	}
+	if !reflect.DeepEqual(got[0].Owners, []string{"self"}) || got[0].ExecutableUsers != nil {
+		t.Errorf("imageQueries() owned query\n%#v", got[0])
+	}
+	if !reflect.DeepEqual(got[1].ExecutableUsers, []string{"self"}) || got[1].Owners != nil {
+		t.Errorf("imageQueries() shared query\n%#v", got[1])
+	}
+	if !reflect.DeepEqual(got[0].Filters, input.Filters) || !reflect.DeepEqual(got[1].Filters, input.Filters) {
+		t.Error("imageQueries() queries do not keep the filters")
+	}
+	if input.Owners != nil || input.ExecutableUsers != nil {
+		t.Error("imageQueries() modified the input")
+	}
+
+	input = &ec2.DescribeImagesInput{Owners: []string{"amazon"}}
+	if got := imageQueries(input); len(got) != 1 || got[0] != input {
+		t.Errorf("imageQueries() with owners\n%#v\nwant\n%#v", got, []*ec2.DescribeImagesInput{input})
+	}
+}
+
+// TestParseImage tests the parseImage function.
+func TestParseImage(t *testing.T) {
+	public := true
+	image := types.Image{
+		ImageId:         common.String("ami-123"),
+		Name:            common.String("web-2026"),
+		OwnerId:         common.String("123456789012"),
+		State:           types.ImageStateAvailable,
+		CreationDate:    common.String("2026-01-01T00:00:00.000Z"),
+		DeprecationTime: common.String("2028-01-01T00:00:00.000Z"),
+		Architecture:    types.ArchitectureValuesArm64,
+		RootDeviceType:  types.DeviceTypeEbs,
+		Public:          &public,
+		Tags:            []types.Tag{{Key: common.String("Team"), Value: common.String("web")}},
+	}
+	want := dataRow{
+		ImageID:         "ami-123",
+		Name:            "web-2026",
+		OwnerID:         "123456789012",
+		State:           "available",
+		CreationDate:    "2026-01-01T00:00:00.000Z",
+		DeprecationTime: "2028-01-01T00:00:00.000Z",
+		Architecture:    "arm64",
+		RootDeviceType:  "ebs",
+		Public:          "true",
+		Tags:            map[string]string{"Team": "web"},
+	}
+	if got := parseImage(&image); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseImage()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_applyUsage tests the applyUsage function.
+func TestResults_applyUsage(t *testing.T) {
+	usage := map[string]int{"ami-1": 3}
+	tests := []struct {
+		name string
+		used []bool
+		want []dataRow
+	}{
+		{
+			name: "no filter",
+			want: []dataRow{{ImageID: "ami-1", UsedBy: 3}, {ImageID: "ami-2", UsedBy: 0}},
+		},
+		{
+			name: "used only",
+			used: []bool{true},
+			want: []dataRow{{ImageID: "ami-1", UsedBy: 3}},
+		},
+		{
+			name: "unused only",
+			used: []bool{false},
+			want: []dataRow{{ImageID: "ami-2", UsedBy: 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Results{Data: []dataRow{{ImageID: "ami-1"}, {ImageID: "ami-2"}}, used: tt.used}
+			r.applyUsage(usage)
+			if !reflect.DeepEqual(r.Data, tt.want) {
+				t.Errorf("Results.applyUsage()\n%#v\nwant\n%#v", r.Data, tt.want)
+			}
+		})
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	r := &Results{Data: []dataRow{{ImageID: "ami-1", UsedBy: 10}, {ImageID: "ami-2", UsedBy: 2}}}
+	if err := r.sortResults("used-by"); err != nil {
+		t.Fatalf("sortResults(used-by) unexpected error: %v", err)
+	}
+	if r.Data[0].ImageID != "ami-2" {
+		t.Errorf("sortResults(used-by) first row = %s, want ami-2", r.Data[0].ImageID)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	want := map[string]string{
+		"id":               "ImageID",
+		"name":             "Name",
+		"owner":            "OwnerID",
+		"state":            "State",
+		"creation-date":    "CreationDate",
+		"deprecation-time": "DeprecationTime",
+		"architecture":     "Architecture",
+		"root-device-type": "RootDeviceType",
+		"public":           "Public",
+		"used-by":          "UsedBy",
+	}
+	got, err := GetSortFields("id")
+	if err != nil {
+		t.Fatalf("GetSortFields() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortFields()\n%#v\nwant\n%#v", got, want)
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ami
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the ami command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids           []string `filter:"image-id"`
+	Names         []string `filter:"name"`
+	Tags          []string `filter:"tag"`
+	TagsKey       []string `filter:"tag-key"`
+	Architectures []string `filter:"architecture"`
+	States        []string `filter:"state"`
+	Public        []string `filter:"is-public"`
+	Used          []string `filter:"used"`
+	Owners        []string `filter:"owner"`
+}
+
+// cmdF holds the values of the ami command filter flags.
+var cmdF = cmdFilters{}
+
+// The AMIs search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "ami",
+	New:           search.WithoutInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"ami-"},
+	IDFilter:      "image-id",
+	Command: &search.Command{
+		Short: "Search for AMIs.",
+		Long: `
+Search for AMIs.
+The AMIs owned by the account and the AMIs shared with it are searched, unless --owners or --ids is given.
+Each AMI shows the number of running instances launched from it in the same account and region.
+You can search AMIs using the following filters:
+  ids, names, tags, tags-key, architectures, states, public, used and owners.
+You can use multiple values for each filter, separated by comma.
+Example: --ids ami-1230456078901,ami-1230456078902
+
+You can use multiple filters at same time. For example, to find the AMIs owned by the account
+that no running instance uses:
+	awss ami --owners self --used false
+
+Use --all to search for all AMIs owned by or shared with the account without any filter.
+This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "AMIs",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter AMIs by IDs. `ami-1230456078901,ami-1230456078902`",
+			},
+			{
+				Name: "names", Shorthand: "n", Value: &cmdF.Names,
+				Usage: "Filter AMIs by names. `'base-*,web-*'`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter AMIs by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter AMIs by tags key. `Key,Environment`",
+			},
+			{
+				Name: "architectures", Shorthand: "A", Value: &cmdF.Architectures,
+				Usage: "Filter AMIs by architecture. `x86_64,arm64`",
+			},
+			{
+				Name: "states", Shorthand: "s", Value: &cmdF.States,
+				Usage: "Filter AMIs by state. `available,pending,failed`",
+			},
+			{
+				Name: "public", Shorthand: "p", Value: &cmdF.Public,
+				Usage: "Filter AMIs by public launch permissions. `true,false`",
+			},
+			{
+				Name: "used", Shorthand: "u", Value: &cmdF.Used,
+				Usage: "Filter AMIs by whether running instances use them. `true,false`",
+			},
+			{
+				Name: "owners", Shorthand: "O", Value: &cmdF.Owners,
+				Usage: "Filter AMIs by owner account IDs or aliases. `self,amazon,123456789012`",
+			},
+		},
+		DefaultSort: "name",
+	},
+})
@@ -86,7 +86,7 @@ func New(profile, region string, filters map[string][]string, sortField string,
 		BaseResults: common.BaseResults{
 			Profile:   profile,
 			Region:    region,
-			Errors:    []string{},
+			Errors:    []common.SearchError{},
 			SortField: sortField,
 		},
 		Data:           []dataRow{},
@@ -101,28 +101,28 @@ func New(profile, region string, filters map[string][]string, sortField string,
 func (r *Results) Search(ctx context.Context) {
 	input, err := r.getFilters()
 	if err != nil {
-		r.Errors = append(r.Errors, fmt.Sprintf("error building filters: %v", err))
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
 		return
 	}
 
-	client, err := r.newEC2Client()
+	client, err := r.newEC2Client(ctx)
 	if err != nil {
-		r.Errors = append(r.Errors, err.Error())
+		r.AddError(err)
 		return
 	}
 
 	instanceIDSet, err := r.collectVolumeRows(ctx, client, input)
 	if err != nil {
-		r.Errors = append(r.Errors, err.Error())
+		r.AddError(err)
 		return
 	}
 
-	r.enrichInstanceNames(instanceIDSet)
+	r.enrichInstanceNames(ctx, instanceIDSet)
 	r.sortIfRequested()
 }
 
-func (r *Results) newEC2Client() (*ec2.Client, error) {
-	cfg, err := common.AwsConfig(r.Profile, r.Region)
+func (r *Results) newEC2Client(ctx context.Context) (*ec2.Client, error) {
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
 	if err != nil {
 		return nil, fmt.Errorf("error getting aws config: %w", err)
 	}
@@ -161,7 +161,7 @@ func (r *Results) appendVolumeRows(volumes []types.Volume, instanceIDSet map[str
 	}
 }
 
-func (r *Results) enrichInstanceNames(instanceIDSet map[string]struct{}) {
+func (r *Results) enrichInstanceNames(ctx context.Context, instanceIDSet map[string]struct{}) {
 	if len(instanceIDSet) == 0 || r.NoInstanceName {
 		return
 	}
@@ -171,9 +171,9 @@ func (r *Results) enrichInstanceNames(instanceIDSet map[string]struct{}) {
 		instanceIDs = append(instanceIDs, id)
 	}
 
-	names, err := searchEC2.SearchInstanceNames(r.Profile, r.Region, instanceIDs)
+	names, err := searchEC2.SearchInstanceNames(ctx, r.Profile, r.Region, instanceIDs)
 	if err != nil {
-		r.Errors = append(r.Errors, err.Error())
+		r.AddError(err)
 		return
 	}
 
@@ -190,7 +190,7 @@ func (r *Results) sortIfRequested() {
 	}
 
 	if err := r.sortResults(r.SortField); err != nil {
-		r.Errors = append(r.Errors, err.Error())
+		r.AddError(err)
 	}
 }
 
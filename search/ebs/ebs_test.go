@@ -55,7 +55,7 @@ func TestNew(t *testing.T) {
 				BaseResults: common.BaseResults{
 					Profile:   "default",
 					Region:    "us-east-1",
-					Errors:    []string{},
+					Errors:    []common.SearchError{},
 					SortField: "id",
 				},
 				Data:    []dataRow{},
@@ -77,7 +77,7 @@ var mockResultsEmpty = &Results{
 	BaseResults: common.BaseResults{
 		Profile: "",
 		Region:  "",
-		Errors:  []string{},
+		Errors:  []common.SearchError{},
 	},
 	Data:    []dataRow{},
 	Filters: map[string][]string{},
@@ -87,9 +87,9 @@ var mockResults = &Results{
 	BaseResults: common.BaseResults{
 		Profile: "default",
 		Region:  "us-east-1",
-		Errors: []string{
-			"error1",
-			"error2",
+		Errors: []common.SearchError{
+			{Category: common.CategoryThrottled, Message: "error1"},
+			{Category: common.CategoryOther, Message: "error2"},
 		},
 		SortField: "id",
 	},
@@ -223,17 +223,20 @@ func TestResults_GetErrors(t *testing.T) {
 	tests := []struct {
 		name    string
 		results *Results
-		want    []string
+		want    []common.SearchError
 	}{
 		{
 			name:    "TestResults_GetErrors",
 			results: mockResults,
-			want:    []string{"error1", "error2"},
+			want: []common.SearchError{
+				{Category: common.CategoryThrottled, Message: "error1"},
+				{Category: common.CategoryOther, Message: "error2"},
+			},
 		},
 		{
 			name:    "TestResults_GetErrors_Empty",
 			results: mockResultsEmpty,
-			want:    []string{},
+			want:    []common.SearchError{},
 		},
 	}
 	for _, tt := range tests {
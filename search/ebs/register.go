@@ -0,0 +1,105 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ebs
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the ebs command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids               []string `filter:"volume-id"`
+	Tags              []string `filter:"tag"`
+	TagsKey           []string `filter:"tag-key"`
+	AvailabilityZones []string `filter:"availability-zone"`
+	Statuses          []string `filter:"status"`
+	VolumeTypes       []string `filter:"volume-type"`
+	InstanceIDs       []string `filter:"attachment.instance-id"`
+	Encrypted         []string `filter:"encrypted"`
+}
+
+// cmdF holds the values of the ebs command filter flags.
+var cmdF = cmdFilters{}
+
+// The EBS volumes search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "ebs",
+	New:           search.WithInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"vol-"},
+	IDFilter:      "volume-id",
+	Command: &search.Command{
+		Short: "Search for EBS volumes.",
+		Long: `
+Search for EBS volumes.
+You can search EBS volumes using the following filters:
+  ids, tags, tags-key, availability-zones, statuses, volume-types, instance-ids, encrypted.
+You can use multiple values for each filter, separated by comma.
+Example: --ids vol-1230456078901,vol-1230456078902
+
+You can use multiple filters at same time, for example:
+	awss ebs -s available,in-use -z a,b
+
+Use --all to search for all EBS volumes without any filter.
+This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "EBS volumes",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter EBS volumes by IDs. `vol-1230456078901,vol-1230456078902`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter EBS volumes by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter EBS volumes by tags key. `Key,Environment`",
+			},
+			{
+				Name: "availability-zones", Shorthand: "z", Value: &cmdF.AvailabilityZones,
+				Usage: "Filter EBS volumes by availability zones. It will append to current region. `a,b`",
+			},
+			{
+				Name: "statuses", Shorthand: "s", Value: &cmdF.Statuses,
+				Usage: "Filter EBS volumes by status. `available,in-use,creating,deleting,deleted,error`",
+			},
+			{
+				Name: "volume-types", Shorthand: "T", Value: &cmdF.VolumeTypes,
+				Usage: "Filter EBS volumes by volume type. `gp2,gp3,io1,io2,st1,sc1,standard`",
+			},
+			{
+				Name: "instance-ids", Shorthand: "I", Value: &cmdF.InstanceIDs,
+				Usage: "Filter EBS volumes by attached instance IDs. `i-1230456078901,i-1230456078902`",
+			},
+			{
+				Name: "encrypted", Shorthand: "e", Value: &cmdF.Encrypted,
+				Usage: "Filter EBS volumes by encryption. `true,false`",
+			},
+		},
+		DefaultSort:    "id",
+		NoInstanceName: true,
+	},
+})
@@ -70,6 +70,9 @@ type dataRow struct {
 
 	// Tags are a map of the tags assigned to the instance.
 	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+
+	// ImageID is the ID of the AMI used to launch the instance.
+	ImageID string `json:"image_id,omitempty"`
 }
 
 // New initiates and returns a new instance of EC2 results.
@@ -78,7 +81,7 @@ func New(profile, region string, filters map[string][]string, sortField string)
 		BaseResults: common.BaseResults{
 			Profile:   profile,
 			Region:    region,
-			Errors:    []string{},
+			Errors:    []common.SearchError{},
 			SortField: sortField,
 		},
 		Data:    []dataRow{},
@@ -93,14 +96,14 @@ func (r *Results) Search(ctx context.Context) {
 	// Get search filters.
 	input, err := r.getFilters()
 	if err != nil {
-		r.Errors = append(r.Errors, fmt.Sprintf("error building filters: %v", err))
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
 		return
 	}
 
 	// Get AWS config.
-	cfg, err := common.AwsConfig(r.Profile, r.Region)
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
 	if err != nil {
-		r.Errors = append(r.Errors, err.Error())
+		r.AddError(err)
 		return
 	}
 
@@ -108,7 +111,7 @@ func (r *Results) Search(ctx context.Context) {
 	client := ec2.NewFromConfig(cfg)
 	response, err := client.DescribeInstances(ctx, input)
 	if err != nil {
-		r.Errors = append(r.Errors, err.Error())
+		r.AddError(err)
 		return
 	}
 
@@ -119,7 +122,7 @@ func (r *Results) Search(ctx context.Context) {
 		}
 	}
 	if err = r.sortResults(r.SortField); err != nil {
-		r.Errors = append(r.Errors, err.Error())
+		r.AddError(err)
 	}
 }
 
@@ -147,6 +150,7 @@ func parseInstance(inst *types.Instance) dataRow {
 		PublicIPAddress:   common.StringValue(inst.PublicIpAddress),
 		NetworkInterfaces: enis,
 		Tags:              common.TagsToMap(inst.Tags),
+		ImageID:           common.StringValue(inst.ImageId),
 	}
 }
 
@@ -254,11 +258,11 @@ func GetSortFields(f string) (map[string]string, error) {
 // SearchInstanceName returns the name of an instance.
 //
 // It returns the value of the tag:Name or empty string in case that the instance has no name.
-func SearchInstanceName(profile, region, instanceID string) (string, error) {
+func SearchInstanceName(ctx context.Context, profile, region, instanceID string) (string, error) {
 	r := New(profile, region, map[string][]string{"instance-id": {instanceID}}, "id")
-	r.Search(context.Background())
+	r.Search(ctx)
 	if len(r.Errors) > 0 {
-		return "", fmt.Errorf("error searching instance name: %v", r.Errors)
+		return "", fmt.Errorf("error searching instance name: %w", common.JoinSearchErrors(r.Errors))
 	}
 
 	switch r.Len() {
@@ -273,14 +277,14 @@ func SearchInstanceName(profile, region, instanceID string) (string, error) {
 
 // SearchInstanceNames returns a map of instanceID to instance name for all given IDs.
 // It makes a single DescribeInstances API call instead of one per ID.
-func SearchInstanceNames(profile, region string, instanceIDs []string) (map[string]string, error) {
+func SearchInstanceNames(ctx context.Context, profile, region string, instanceIDs []string) (map[string]string, error) {
 	if len(instanceIDs) == 0 {
 		return map[string]string{}, nil
 	}
 	r := New(profile, region, map[string][]string{"instance-id": instanceIDs}, "id")
-	r.Search(context.Background())
+	r.Search(ctx)
 	if len(r.Errors) > 0 {
-		return nil, fmt.Errorf("error searching instance names: %v", r.Errors)
+		return nil, fmt.Errorf("error searching instance names: %w", common.JoinSearchErrors(r.Errors))
 	}
 	names := make(map[string]string, len(r.Data))
 	for i := range r.Data {
@@ -288,3 +292,25 @@ func SearchInstanceNames(profile, region string, instanceIDs []string) (map[stri
 	}
 	return names, nil
 }
+
+// SearchImageUsage returns a map of imageID to the number of running instances launched from it.
+//
+// The images without running instances are not in the map.
+// The instances are searched in chunks of common.MaxFilterValues image IDs.
+func SearchImageUsage(ctx context.Context, profile, region string, imageIDs []string) (map[string]int, error) {
+	usage := map[string]int{}
+	for _, chunk := range common.ChunkValues(imageIDs, common.MaxFilterValues) {
+		r := New(profile, region, map[string][]string{
+			"image-id":            chunk,
+			"instance-state-name": {string(types.InstanceStateNameRunning)},
+		}, "id")
+		r.Search(ctx)
+		if len(r.Errors) > 0 {
+			return nil, fmt.Errorf("error searching image usage: %w", common.JoinSearchErrors(r.Errors))
+		}
+		for i := range r.Data {
+			usage[r.Data[i].ImageID]++
+		}
+	}
+	return usage, nil
+}
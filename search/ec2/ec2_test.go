@@ -54,7 +54,7 @@ func TestNew(t *testing.T) {
 				BaseResults: common.BaseResults{
 					Profile:   "default",
 					Region:    "us-east-1",
-					Errors:    []string{},
+					Errors:    []common.SearchError{},
 					SortField: "id",
 				},
 				Data:    []dataRow{},
@@ -76,7 +76,7 @@ var mockResultsEmpty = &Results{
 	BaseResults: common.BaseResults{
 		Profile: "",
 		Region:  "",
-		Errors:  []string{},
+		Errors:  []common.SearchError{},
 	},
 	Data:    []dataRow{},
 	Filters: map[string][]string{},
@@ -86,9 +86,9 @@ var mockResults = &Results{
 	BaseResults: common.BaseResults{
 		Profile: "default",
 		Region:  "us-east-1",
-		Errors: []string{
-			"error1",
-			"error2",
+		Errors: []common.SearchError{
+			{Category: common.CategoryThrottled, Message: "error1"},
+			{Category: common.CategoryOther, Message: "error2"},
 		},
 		SortField: "id",
 	},
@@ -236,17 +236,20 @@ func TestResults_GetErrors(t *testing.T) {
 	tests := []struct {
 		name    string
 		results *Results
-		want    []string
+		want    []common.SearchError
 	}{
 		{
 			name:    "TestResults_GetErrors",
 			results: mockResults,
-			want:    []string{"error1", "error2"},
+			want: []common.SearchError{
+				{Category: common.CategoryThrottled, Message: "error1"},
+				{Category: common.CategoryOther, Message: "error2"},
+			},
 		},
 		{
 			name:    "TestResults_GetErrors_Empty",
 			results: mockResultsEmpty,
-			want:    []string{},
+			want:    []common.SearchError{},
 		},
 	}
 	for _, tt := range tests {
@@ -481,7 +484,7 @@ func TestGetSortFields(t *testing.T) {
 // 	}
 // 	for _, tt := range tests {
 // 		t.Run(tt.name, func(t *testing.T) {
-// 			got, err := SearchInstanceName(tt.args.profile, tt.args.region, tt.args.instanceID)
+// 			got, err := SearchInstanceName(context.Background(), tt.args.profile, tt.args.region, tt.args.instanceID)
 // 			if (err != nil) != tt.wantErr {
 // 				t.Errorf("SearchInstanceName() error = %v, wantErr %v", err, tt.wantErr)
 // 				return
@@ -0,0 +1,109 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"net"
+
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the ec2 command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids               []string `filter:"instance-id"`
+	Names             []string `filter:"tag:Name"`
+	Tags              []string `filter:"tag"`
+	TagsKey           []string `filter:"tag-key"`
+	InstanceTypes     []string `filter:"instance-type"`
+	InstanceStates    []string `filter:"instance-state-name"`
+	AvailabilityZones []string `filter:"availability-zone"`
+	PrivateIPs        []net.IP `filter:"network-interface.addresses.private-ip-address"`
+	PublicIPs         []net.IP `filter:"network-interface.addresses.association.public-ip"`
+}
+
+// cmdF holds the values of the ec2 command filter flags.
+var cmdF = cmdFilters{}
+
+// The EC2 instances search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "ec2",
+	New:           search.WithoutInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"i-"},
+	IDFilter:      "instance-id",
+	Command: &search.Command{
+		Short: "Search for EC2 instances.",
+		Long: `
+Search for EC2 instances.
+You can search EC2 instances using the following filters:
+  ids, names, tags, instance-types, availability-zones, instance-states, private-ips and public-ips.
+You can use multiple values for each filter, separated by comma. Example: --names 'Name1,Name2'
+
+You can use multiple filters at same time, for example:
+	awss ec2 -n '*' -t 'Key=Value1:Value2,Environment=Production' -T t2.micro,t2.small -z a,b -s running,stopped
+
+Use --all to search for all EC2 instances without any filter. This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "EC2 instances",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter EC2 instances by ids. `i-1230456078901,i-1230456078902`",
+			},
+			{
+				Name: "names", Shorthand: "n", Value: &cmdF.Names,
+				Usage: "Filter EC2 instances by names. It searches using the 'tag:Name'. `instance-1,instance-2`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter EC2 instances by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter EC2 instances by tags key. `Key,Environment`",
+			},
+			{
+				Name: "instance-types", Shorthand: "T", Value: &cmdF.InstanceTypes,
+				Usage: "Filter EC2 instances by instance type. `t2.micro,t2.small`",
+			},
+			{
+				Name: "availability-zones", Shorthand: "z", Value: &cmdF.AvailabilityZones,
+				Usage: "Filter EC2 instances by availability zones. It will append to current region. `a,b`",
+			},
+			{
+				Name: "instance-states", Shorthand: "s", Value: &cmdF.InstanceStates,
+				Usage: "Filter EC2 instances by instance state. `running,stopped`",
+			},
+			{
+				Name: "private-ips", Shorthand: "p", Value: &cmdF.PrivateIPs,
+				Usage: "Filter EC2 instances by private IPs. `172.16.0.1,172.17.1.254`",
+			},
+			{
+				Name: "public-ips", Shorthand: "P", Value: &cmdF.PublicIPs,
+				Usage: "Filter EC2 instances by public IPs. `52.28.19.20,52.30.31.32`",
+			},
+		},
+		DefaultSort: "name",
+	},
+})
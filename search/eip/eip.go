@@ -0,0 +1,292 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eip contains the search for Elastic IPs.
+//
+// It implements the common.Results interface.
+package eip
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/dyegoe/awss/common"
+	searchEC2 "github.com/dyegoe/awss/search/ec2"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// FilterAssociated is the filter key used to keep only the associated or unassociated addresses.
+//
+// The EC2 API has no filter for it, so it is applied to the results. Values are `true` and/or `false`.
+const FilterAssociated = "associated"
+
+// Results describes results of the Elastic IPs search.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the Elastic IPs found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search.
+	Filters map[string][]string `json:"-"`
+
+	// NoInstanceName skips the instance name lookup when true.
+	NoInstanceName bool `json:"-"`
+
+	// associated holds the accepted association states parsed from the "associated" filter.
+	// It is empty when the filter is not used.
+	associated []bool
+}
+
+// dataRow represents a row of the Elastic IPs search results.
+type dataRow struct {
+	// AllocationID is the allocation ID of the Elastic IP.
+	AllocationID string `json:"id,omitempty" header:"ID" sort:"id"`
+
+	// PublicIP is the Elastic IP address.
+	PublicIP string `json:"public_ip,omitempty" header:"Public IP" sort:"public-ip"`
+
+	// AssociationID is the ID of the association with an instance or network interface.
+	AssociationID string `json:"association_id,omitempty" header:"Association ID" sort:"association-id"`
+
+	// InstanceID is the ID of the instance the address is associated with.
+	InstanceID string `json:"instance_id,omitempty" header:"Instance ID" sort:"instance-id"`
+
+	// InstanceName is the name of the instance the address is associated with.
+	InstanceName string `json:"instance_name,omitempty" header:"Instance Name" sort:"instance-name"`
+
+	// NetworkInterfaceID is the ID of the network interface the address is associated with.
+	NetworkInterfaceID string `json:"eni_id,omitempty" header:"ENI ID" sort:"eni-id"`
+
+	// PrivateIP is the private IP address associated with the Elastic IP.
+	PrivateIP string `json:"private_ip,omitempty" header:"Private IP" sort:"private-ip"`
+
+	// NetworkBorderGroup is the location from which the address is advertised.
+	NetworkBorderGroup string `json:"network_border_group,omitempty" header:"Network Border Group" sort:"border-group"`
+
+	// Tags are the tags assigned to the Elastic IP.
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// New initiates and returns a new instance of Elastic IPs results.
+func New(profile, region string, filters map[string][]string, sortField string, noInstanceName bool) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:           []dataRow{},
+		Filters:        filters,
+		NoInstanceName: noInstanceName,
+	}
+}
+
+// Search performs the Elastic IPs search.
+//
+// Results are stored in the Data field.
+func (r *Results) Search(ctx context.Context) {
+	input, err := r.getFilters()
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+
+	response, err := ec2.NewFromConfig(cfg).DescribeAddresses(ctx, input)
+	if err != nil {
+		r.AddError(fmt.Errorf("error describing addresses: %w", err))
+		return
+	}
+	for _, address := range response.Addresses { //nolint:gocritic
+		row := parseAddress(&address)
+		if !common.MatchBool(row.AssociationID != "", r.associated) {
+			continue
+		}
+		r.Data = append(r.Data, row)
+	}
+
+	r.enrichInstanceNames(ctx)
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// parseAddress converts a single Address into a dataRow.
+func parseAddress(address *types.Address) dataRow {
+	return dataRow{
+		AllocationID:       common.StringValue(address.AllocationId),
+		PublicIP:           common.StringValue(address.PublicIp),
+		AssociationID:      common.StringValue(address.AssociationId),
+		InstanceID:         common.StringValue(address.InstanceId),
+		NetworkInterfaceID: common.StringValue(address.NetworkInterfaceId),
+		PrivateIP:          common.StringValue(address.PrivateIpAddress),
+		NetworkBorderGroup: common.StringValue(address.NetworkBorderGroup),
+		Tags:               common.TagsToMap(address.Tags),
+	}
+}
+
+// enrichInstanceNames sets the name of the instances the addresses are associated with.
+func (r *Results) enrichInstanceNames(ctx context.Context) {
+	if r.NoInstanceName {
+		return
+	}
+
+	instanceIDSet := map[string]struct{}{}
+	for i := range r.Data {
+		if id := r.Data[i].InstanceID; id != "" {
+			instanceIDSet[id] = struct{}{}
+		}
+	}
+	if len(instanceIDSet) == 0 {
+		return
+	}
+
+	instanceIDs := make([]string, 0, len(instanceIDSet))
+	for id := range instanceIDSet {
+		instanceIDs = append(instanceIDs, id)
+	}
+
+	names, err := searchEC2.SearchInstanceNames(ctx, r.Profile, r.Region, instanceIDs)
+	if err != nil {
+		r.AddError(err)
+		return
+	}
+
+	for i := range r.Data {
+		if id := r.Data[i].InstanceID; id != "" {
+			r.Data[i].InstanceName = names[id]
+		}
+	}
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getFilters returns the filters used to search.
+//
+// The filters are defined in the results.Filters field.
+// The "associated" filter is not an AWS filter. It is parsed into results.associated and applied to the results.
+// Except for "allocation-id", "public-ip", "tag" and "associated", all other filters are passed as-is.
+func (r *Results) getFilters() (*ec2.DescribeAddressesInput, error) {
+	input := ec2.DescribeAddressesInput{}
+
+	for key, values := range r.Filters {
+		switch key {
+		case "allocation-id":
+			input.AllocationIds = values
+		case "public-ip":
+			input.PublicIps = values
+		case "tag":
+			tagFilters, err := common.FilterTags(values)
+			if err != nil {
+				return nil, fmt.Errorf("building tag filters: %w", err)
+			}
+			input.Filters = append(input.Filters, tagFilters...)
+		case FilterAssociated:
+			associated, err := common.ParseBools(key, values)
+			if err != nil {
+				return nil, err
+			}
+			r.associated = associated
+		default:
+			input.Filters = append(input.Filters, common.FilterDefault(key, values)...)
+		}
+	}
+	return &input, nil
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	fieldName := sortFields[field]
+	sort.Slice(r.Data, func(p, q int) bool {
+		return reflect.ValueOf(r.Data[p]).FieldByName(fieldName).String() <
+			reflect.ValueOf(r.Data[q]).FieldByName(fieldName).String()
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eip contains the search for Elastic IPs.
+//
+// It implements the common.Results interface.
+package eip
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{"allocation-id": {"eipalloc-123"}}, "id", true)
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "id",
+		},
+		Data:           []dataRow{},
+		Filters:        map[string][]string{"allocation-id": {"eipalloc-123"}},
+		NoInstanceName: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	want := []interface{}{
+		"ID", "Public IP", "Association ID", "Instance ID", "Instance Name",
+		"ENI ID", "Private IP", "Network Border Group", "Tags",
+	}
+	if got := New("", "", nil, "", false).GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_getFilters tests the getFilters function.
+func TestResults_getFilters(t *testing.T) {
+	r := New("default", "us-east-1", map[string][]string{
+		"allocation-id":  {"eipalloc-123"},
+		"public-ip":      {"1.1.1.1"},
+		"tag":            {"key=value"},
+		"instance-id":    {"i-123"},
+		FilterAssociated: {"false"},
+	}, "id", false)
+	got, err := r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.AllocationIds, []string{"eipalloc-123"}) {
+		t.Errorf("AllocationIds = %v, want [eipalloc-123]", got.AllocationIds)
+	}
+	if !reflect.DeepEqual(got.PublicIps, []string{"1.1.1.1"}) {
+		t.Errorf("PublicIps = %v, want [1.1.1.1]", got.PublicIps)
+	}
+	if !reflect.DeepEqual(r.associated, []bool{false}) {
+		t.Errorf("associated = %v, want [false]", r.associated)
+	}
+	want := map[string][]string{
+		"tag:key":     {"value"},
+		"instance-id": {"i-123"},
+	}
+	gotByName := make(map[string][]string, len(got.Filters))
+	for _, f := range got.Filters {
+		gotByName[*f.Name] = f.Values
+	}
+	if !reflect.DeepEqual(gotByName, want) {
+		t.Errorf("Results.getFilters()\n%#v\nwant\n%#v", gotByName, want)
+	}
+
+	r.Filters = map[string][]string{"tag": {"invalid"}}
+	if _, err := r.getFilters(); err == nil {
+		t.Error("Results.getFilters() expected error for malformed tag, got nil")
+	}
+	r.Filters = map[string][]string{FilterAssociated: {"maybe"}}
+	if _, err := r.getFilters(); err == nil {
+		t.Error("Results.getFilters() expected error for invalid associated value, got nil")
+	}
+}
+
+// TestParseAddress tests the parseAddress function.
+func TestParseAddress(t *testing.T) {
+	address := types.Address{
+		AllocationId:       common.String("eipalloc-123"),
+		PublicIp:           common.String("1.1.1.1"),
+		AssociationId:      common.String("eipassoc-123"),
+		InstanceId:         common.String("i-123"),
+		NetworkInterfaceId: common.String("eni-123"),
+		PrivateIpAddress:   common.String("10.0.0.10"),
+		NetworkBorderGroup: common.String("us-east-1"),
+		Tags:               []types.Tag{{Key: common.String("Name"), Value: common.String("web")}},
+	}
+	want := dataRow{
+		AllocationID:       "eipalloc-123",
+		PublicIP:           "1.1.1.1",
+		AssociationID:      "eipassoc-123",
+		InstanceID:         "i-123",
+		NetworkInterfaceID: "eni-123",
+		PrivateIP:          "10.0.0.10",
+		NetworkBorderGroup: "us-east-1",
+		Tags:               map[string]string{"Name": "web"},
+	}
+	if got := parseAddress(&address); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAddress()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	r := &Results{Data: []dataRow{
+		{AllocationID: "eipalloc-2", PublicIP: "1.1.1.1"},
+		{AllocationID: "eipalloc-1", PublicIP: "2.2.2.2"},
+	}}
+	if err := r.sortResults("id"); err != nil {
+		t.Fatalf("sortResults(id) unexpected error: %v", err)
+	}
+	if r.Data[0].AllocationID != "eipalloc-1" {
+		t.Errorf("sortResults(id) first row = %s, want eipalloc-1", r.Data[0].AllocationID)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	want := map[string]string{
+		"id":             "AllocationID",
+		"public-ip":      "PublicIP",
+		"association-id": "AssociationID",
+		"instance-id":    "InstanceID",
+		"instance-name":  "InstanceName",
+		"eni-id":         "NetworkInterfaceID",
+		"private-ip":     "PrivateIP",
+		"border-group":   "NetworkBorderGroup",
+	}
+	got, err := GetSortFields("id")
+	if err != nil {
+		t.Fatalf("GetSortFields() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortFields()\n%#v\nwant\n%#v", got, want)
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}
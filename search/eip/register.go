@@ -0,0 +1,109 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eip
+
+import (
+	"net"
+
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the eip command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids                 []string `filter:"allocation-id"`
+	PublicIPs           []net.IP `filter:"public-ip"`
+	PrivateIPs          []net.IP `filter:"private-ip-address"`
+	Tags                []string `filter:"tag"`
+	TagsKey             []string `filter:"tag-key"`
+	InstanceIDs         []string `filter:"instance-id"`
+	NetworkInterfaceIDs []string `filter:"network-interface-id"`
+	Associated          []string `filter:"associated"`
+}
+
+// cmdF holds the values of the eip command filter flags.
+var cmdF = cmdFilters{}
+
+// The Elastic IPs search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "eip",
+	New:           search.WithInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"eipalloc-"},
+	IDFilter:      "allocation-id",
+	Command: &search.Command{
+		Short: "Search for Elastic IPs.",
+		Long: `
+Search for Elastic IPs.
+You can search Elastic IPs using the following filters:
+  ids, public-ips, private-ips, tags, tags-key, instance-ids, eni-ids and associated.
+You can use multiple values for each filter, separated by comma.
+Example: --ids eipalloc-1230456078901,eipalloc-1230456078902
+
+You can use multiple filters at same time, for example:
+	awss eip -t 'Environment=prod' -A false
+
+Use --associated false to list the unassociated Elastic IPs, which are billed while idle.
+
+Use --all to search for all Elastic IPs without any filter.
+This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "Elastic IPs",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter Elastic IPs by allocation IDs. `eipalloc-1230456078901,eipalloc-1230456078902`",
+			},
+			{
+				Name: "public-ips", Shorthand: "p", Value: &cmdF.PublicIPs,
+				Usage: "Filter Elastic IPs by public IPs. `1.1.1.1,2.2.2.2`",
+			},
+			{
+				Name: "private-ips", Shorthand: "r", Value: &cmdF.PrivateIPs,
+				Usage: "Filter Elastic IPs by associated private IPs. `172.16.0.1,172.17.1.254`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter Elastic IPs by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter Elastic IPs by tags key. `Key,Environment`",
+			},
+			{
+				Name: "instance-ids", Shorthand: "I", Value: &cmdF.InstanceIDs,
+				Usage: "Filter Elastic IPs by associated instance IDs. `i-1230456078901,i-1230456078902`",
+			},
+			{
+				Name: "eni-ids", Shorthand: "e", Value: &cmdF.NetworkInterfaceIDs,
+				Usage: "Filter Elastic IPs by associated network interface IDs. `eni-1230456078901,eni-1230456078902`",
+			},
+			{
+				Name: "associated", Shorthand: "A", Value: &cmdF.Associated,
+				Usage: "Filter Elastic IPs by association. Use false for the unassociated ones. `true,false`",
+			},
+		},
+		DefaultSort:    "id",
+		NoInstanceName: true,
+	},
+})
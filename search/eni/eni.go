@@ -57,8 +57,19 @@ type dataRow struct {
 	// PublicIPAddresses are the public IP addresses or Elastic IP addresses bound to the network interface.
 	PublicIPAddresses []string `json:"public_ips,omitempty" header:"Public IPs"`
 
+	// SecurityGroups are the IDs of the security groups associated with the network interface.
+	// It is not shown, it is used by the security groups audit.
+	SecurityGroups []string `json:"-"`
+
+	// IPv6Addresses are the IPv6 addresses assigned to the network interface.
+	// It is not shown, it is used by the IP addresses lookup.
+	IPv6Addresses []string `json:"-"`
+
 	// Tags are the tags assigned to the network interface.
 	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+
+	// Description is the description of the network interface. It is not shown in the table.
+	Description string `json:"description,omitempty"`
 }
 
 // eniInfo represents the network interface info.
@@ -91,7 +102,7 @@ func New(profile, region string, filters map[string][]string, sortField string,
 		BaseResults: common.BaseResults{
 			Profile:   profile,
 			Region:    region,
-			Errors:    []string{},
+			Errors:    []common.SearchError{},
 			SortField: sortField,
 		},
 		Data:           []dataRow{},
@@ -107,14 +118,14 @@ func (r *Results) Search(ctx context.Context) {
 	// Get search filters.
 	input, err := r.getFilters()
 	if err != nil {
-		r.Errors = append(r.Errors, fmt.Sprintf("error building filters: %v", err))
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
 		return
 	}
 
 	// Get AWS config.
-	cfg, err := common.AwsConfig(r.Profile, r.Region)
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
 	if err != nil {
-		r.Errors = append(r.Errors, fmt.Sprintf("error getting aws config: %s", err))
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
 		return
 	}
 
@@ -122,7 +133,7 @@ func (r *Results) Search(ctx context.Context) {
 	client := ec2.NewFromConfig(cfg)
 	response, err := client.DescribeNetworkInterfaces(ctx, input)
 	if err != nil {
-		r.Errors = append(r.Errors, fmt.Sprintf("error describing network interfaces: %v", err))
+		r.AddError(fmt.Errorf("error describing network interfaces: %w", err))
 		return
 	}
 
@@ -137,9 +148,9 @@ func (r *Results) Search(ctx context.Context) {
 
 	// Batch lookup instance names in a single API call.
 	if len(instanceIDs) > 0 && !r.NoInstanceName {
-		names, err := searchEC2.SearchInstanceNames(r.Profile, r.Region, instanceIDs)
+		names, err := searchEC2.SearchInstanceNames(ctx, r.Profile, r.Region, instanceIDs)
 		if err != nil {
-			r.Errors = append(r.Errors, err.Error())
+			r.AddError(err)
 		} else {
 			for i := range r.Data {
 				if id := r.Data[i].InterfaceInfo.InstanceID; id != "" {
@@ -151,7 +162,7 @@ func (r *Results) Search(ctx context.Context) {
 
 	if r.SortField != "" {
 		if err := r.sortResults(r.SortField); err != nil {
-			r.Errors = append(r.Errors, err.Error())
+			r.AddError(err)
 		}
 	}
 }
@@ -166,11 +177,18 @@ func parseENIRow(eni *types.NetworkInterface) dataRow {
 			SubnetID:           common.StringValue(eni.SubnetId),
 			Status:             string(eni.Status),
 		},
-		Tags: common.TagsToMap(eni.TagSet),
+		Tags:        common.TagsToMap(eni.TagSet),
+		Description: common.StringValue(eni.Description),
 	}
 	if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
 		row.InterfaceInfo.InstanceID = *eni.Attachment.InstanceId
 	}
+	for _, group := range eni.Groups {
+		row.SecurityGroups = append(row.SecurityGroups, common.StringValue(group.GroupId))
+	}
+	for _, ip := range eni.Ipv6Addresses {
+		row.IPv6Addresses = append(row.IPv6Addresses, common.StringValue(ip.Ipv6Address))
+	}
 	for _, ip := range eni.PrivateIpAddresses {
 		row.PrivateIPAddresses = append(row.PrivateIPAddresses, common.StringValue(ip.PrivateIpAddress))
 		if ip.Association != nil {
@@ -55,7 +55,7 @@ func TestNew(t *testing.T) {
 				BaseResults: common.BaseResults{
 					Profile:   "default",
 					Region:    "us-east-1",
-					Errors:    []string{},
+					Errors:    []common.SearchError{},
 					SortField: "id",
 				},
 				Data:    []dataRow{},
@@ -77,7 +77,7 @@ var mockResultsEmpty = &Results{
 	BaseResults: common.BaseResults{
 		Profile: "",
 		Region:  "",
-		Errors:  []string{},
+		Errors:  []common.SearchError{},
 	},
 	Data:    []dataRow{},
 	Filters: map[string][]string{},
@@ -87,9 +87,9 @@ var mockResults = &Results{
 	BaseResults: common.BaseResults{
 		Profile: "default",
 		Region:  "us-east-1",
-		Errors: []string{
-			"error1",
-			"error2",
+		Errors: []common.SearchError{
+			{Category: common.CategoryThrottled, Message: "error1"},
+			{Category: common.CategoryOther, Message: "error2"},
 		},
 		SortField: "id",
 	},
@@ -246,17 +246,20 @@ func TestResults_GetErrors(t *testing.T) {
 	tests := []struct {
 		name    string
 		results *Results
-		want    []string
+		want    []common.SearchError
 	}{
 		{
 			name:    "TestResults_GetErrors",
 			results: mockResults,
-			want:    []string{"error1", "error2"},
+			want: []common.SearchError{
+				{Category: common.CategoryThrottled, Message: "error1"},
+				{Category: common.CategoryOther, Message: "error2"},
+			},
 		},
 		{
 			name:    "TestResults_GetErrors_Empty",
 			results: mockResultsEmpty,
-			want:    []string{},
+			want:    []common.SearchError{},
 		},
 	}
 	for _, tt := range tests {
@@ -384,3 +387,41 @@ func TestResults_getFilters(t *testing.T) {
 		})
 	}
 }
+
+// TestParseENIRow tests the parseENIRow function.
+func TestParseENIRow(t *testing.T) {
+	eni := types.NetworkInterface{
+		NetworkInterfaceId: common.String("eni-1234567890abcdef0"),
+		InterfaceType:      types.NetworkInterfaceTypeInterface,
+		AvailabilityZone:   common.String("us-east-1a"),
+		Status:             types.NetworkInterfaceStatusInUse,
+		Attachment:         &types.NetworkInterfaceAttachment{InstanceId: common.String("i-1234567890abcdef0")},
+		Groups:             []types.GroupIdentifier{{GroupId: common.String("sg-1234567890abcdef0")}},
+		Ipv6Addresses:      []types.NetworkInterfaceIpv6Address{{Ipv6Address: common.String("2600:1f18::1")}},
+		Description:        common.String("web server"),
+		PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+			{
+				PrivateIpAddress: common.String("172.16.0.1"),
+				Association:      &types.NetworkInterfaceAssociation{PublicIp: common.String("51.52.53.54")},
+			},
+		},
+	}
+	want := dataRow{
+		InterfaceInfo: eniInfo{
+			NetworkInterfaceID: "eni-1234567890abcdef0",
+			InterfaceType:      "interface",
+			AvailabilityZone:   "us-east-1a",
+			Status:             "in-use",
+			InstanceID:         "i-1234567890abcdef0",
+		},
+		PrivateIPAddresses: []string{"172.16.0.1"},
+		PublicIPAddresses:  []string{"51.52.53.54"},
+		SecurityGroups:     []string{"sg-1234567890abcdef0"},
+		IPv6Addresses:      []string{"2600:1f18::1"},
+		Tags:               map[string]string{},
+		Description:        "web server",
+	}
+	if got := parseENIRow(&eni); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseENIRow()\n%#v\nwant\n%#v", got, want)
+	}
+}
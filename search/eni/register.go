@@ -0,0 +1,99 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eni
+
+import (
+	"net"
+
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the eni command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids               []string `filter:"network-interface-id"`
+	Tags              []string `filter:"tag"`
+	TagsKey           []string `filter:"tag-key"`
+	InstanceIDs       []string `filter:"attachment.instance-id"`
+	AvailabilityZones []string `filter:"availability-zone"`
+	PrivateIPs        []net.IP `filter:"addresses.private-ip-address"`
+	PublicIPs         []net.IP `filter:"association.public-ip"`
+}
+
+// cmdF holds the values of the eni command filter flags.
+var cmdF = cmdFilters{}
+
+// The ENIs search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "eni",
+	New:           search.WithInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"eni-"},
+	IDFilter:      "network-interface-id",
+	Command: &search.Command{
+		Short: "Search for ENIs (Elastic Network Interfaces).",
+		Long: `
+Search for ENIs (Elastic Network Interfaces).
+You can search ENIs using the following filters: ids, tags, instance-ids, availability-zones, private-ips, public-ips.
+You can use multiple values for each filter, separated by comma. Example: --ids eni-1230456078901,eni-1230456078902
+
+You can use multiple filters at same time, for example:
+	awss eni -I i-1230456078901,i-1230456078902 -z a,b
+
+Use --all to search for all ENIs without any filter. This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "ENIs",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter ENIs by ids. `eni-1230456078901,eni-1230456078902`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter ENIs by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter ENIs by tags key. `Key,Environment`",
+			},
+			{
+				Name: "instance-ids", Shorthand: "I", Value: &cmdF.InstanceIDs,
+				Usage: "Filter ENIs by instance IDs. `i-1230456078901,i-1230456078902`",
+			},
+			{
+				Name: "availability-zones", Shorthand: "z", Value: &cmdF.AvailabilityZones,
+				Usage: "Filter ENIs by availability zones. It will append to current region. `a,b`",
+			},
+			{
+				Name: "private-ips", Shorthand: "p", Value: &cmdF.PrivateIPs,
+				Usage: "Filter ENIs by private IPs. `172.16.0.1,172.17.1.254`",
+			},
+			{
+				Name: "public-ips", Shorthand: "P", Value: &cmdF.PublicIPs,
+				Usage: "Filter ENIs by public IPs. `52.28.19.20,52.30.31.32`",
+			},
+		},
+		DefaultSort:    "id",
+		NoInstanceName: true,
+	},
+})
@@ -0,0 +1,154 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// findSortField is the sort field used by the find command. Every resource has an "id" sort field.
+const findSortField = "id"
+
+// ResolveID returns the search used to find the resource with the given ID.
+//
+// The resource type is inferred from the ID prefix, matched against the IDPrefixes of the registered resources.
+// It returns an error if the prefix is unknown.
+func ResolveID(id string) (cmd, filter string, err error) {
+	prefix, _, ok := strings.Cut(id, "-")
+	if !ok || prefix == "" {
+		return "", "", fmt.Errorf("invalid resource id: %s", id)
+	}
+	for _, r := range Resources() { //nolint:gocritic
+		if common.StringInSlice(prefix+"-", r.IDPrefixes) {
+			return r.Name, r.IDFilter, nil
+		}
+	}
+	return "", "", fmt.Errorf("unsupported resource id prefix: %s-. The supported prefixes are: %s",
+		prefix, common.StringSliceToString(supportedIDPrefixes(), ", "))
+}
+
+// supportedIDPrefixes returns the sorted list of the ID prefixes of the registered resources.
+func supportedIDPrefixes() []string {
+	prefixes := []string{}
+	for _, r := range Resources() { //nolint:gocritic
+		prefixes = append(prefixes, r.IDPrefixes...)
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// Find finds the resource with the given ID in the given profiles and regions.
+//
+// The credentials of the profiles are checked first, see checkCredentials.
+// The searches run in parallel and are cancelled as soon as the resource is found.
+// Only the results containing the resource are printed.
+// If the resource is not found, the results with errors are printed and an error is returned.
+// The searches are stopped when the context is done.
+func Find(parent context.Context, id string, profiles, regions []string, output string, showTags bool) error {
+	cmd, filter, err := ResolveID(id)
+	if err != nil {
+		return err
+	}
+	resource, err := lookup(cmd)
+	if err != nil {
+		return err
+	}
+
+	// The profiles with invalid credentials are not searched, see checkCredentials.
+	if len(profiles) > 0 && len(regions) > 0 {
+		skipped, err := checkCredentials(parent, profiles)
+		if err != nil {
+			return err
+		}
+		valid := []string{}
+		for _, profile := range profiles {
+			if _, skip := skipped[profile]; !skip {
+				valid = append(valid, profile)
+			}
+		}
+		profiles = valid
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	resultsChan := findAll(ctx, resource.New, profiles, regions, map[string][]string{filter: {id}})
+
+	found, failed := []common.Results{}, []common.Results{}
+	for results := range resultsChan {
+		switch {
+		case results.Len() > 0:
+			found = append(found, results)
+			cancel()
+		case ctx.Err() == nil && !onlyNotFoundErrors(results.GetErrors()):
+			failed = append(failed, results)
+		}
+	}
+
+	if len(found) > 0 {
+		printResults(found, output, false, showTags)
+		return nil
+	}
+	printResults(failed, output, true, showTags)
+	if err := stopped(parent); err != nil {
+		return fmt.Errorf("resource %s not found, %w", id, err)
+	}
+	return fmt.Errorf("resource %s not found", id)
+}
+
+// findAll runs the search with the given filters in all the given profiles and regions in parallel, see searchAll.
+//
+// The returned channel is closed when all the searches are done.
+func findAll(
+	ctx context.Context,
+	newResults NewResultsFunc,
+	profiles, regions []string,
+	filters map[string][]string,
+) <-chan common.Results {
+	resultsChan := make(chan common.Results, len(profiles)*len(regions))
+
+	pending := make([]common.Results, 0, len(profiles)*len(regions))
+	for _, profile := range profiles {
+		for _, region := range regions {
+			pending = append(pending, newResults(profile, region, filters, findSortField, false))
+		}
+	}
+
+	go func() {
+		searchAll(ctx, pending, resultsChan)
+		close(resultsChan)
+	}()
+
+	return resultsChan
+}
+
+// onlyNotFoundErrors returns true if all the errors are the AWS "not found" errors.
+//
+// Searching an ID in a region where it does not exist returns a NotFound error, e.g. InvalidInstanceID.NotFound.
+func onlyNotFoundErrors(errors []common.SearchError) bool {
+	for _, e := range errors {
+		if e.Category != common.CategoryNotFound {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// TestResolveID tests the ResolveID function.
+func TestResolveID(t *testing.T) {
+	oldRegistry := registry
+	defer func() { registry = oldRegistry }()
+	registry = map[string]Resource{
+		"ec2":    {Name: "ec2", IDPrefixes: []string{"i-"}, IDFilter: "instance-id"},
+		"eni":    {Name: "eni", IDPrefixes: []string{"eni-"}, IDFilter: "network-interface-id"},
+		"subnet": {Name: "subnet", IDPrefixes: []string{"subnet-"}, IDFilter: "subnet-id"},
+		"ip":     {Name: "ip"},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		wantCmd    string
+		wantFilter string
+		wantErr    bool
+	}{
+		{name: "instance", id: "i-1234567890abcdef0", wantCmd: "ec2", wantFilter: "instance-id"},
+		{name: "network interface", id: "eni-1234567890abcdef0", wantCmd: "eni", wantFilter: "network-interface-id"},
+		{name: "subnet", id: "subnet-1234567890abcdef0", wantCmd: "subnet", wantFilter: "subnet-id"},
+		{name: "unsupported prefix", id: "foo-1234567890abcdef0", wantErr: true},
+		{name: "no prefix", id: "1234567890abcdef0", wantErr: true},
+		{name: "empty prefix", id: "-1234567890abcdef0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, filter, err := ResolveID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cmd != tt.wantCmd || filter != tt.wantFilter {
+				t.Errorf("ResolveID() = %q, %q, want %q, %q", cmd, filter, tt.wantCmd, tt.wantFilter)
+			}
+		})
+	}
+}
+
+// TestOnlyNotFoundErrors tests the onlyNotFoundErrors function.
+func TestOnlyNotFoundErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		errors []common.SearchError
+		want   bool
+	}{
+		{name: "no errors", errors: []common.SearchError{}, want: true},
+		{
+			name:   "not found",
+			errors: []common.SearchError{{Category: common.CategoryNotFound, Message: "InvalidInstanceID.NotFound"}},
+			want:   true,
+		},
+		{
+			name: "not found and access denied",
+			errors: []common.SearchError{
+				{Category: common.CategoryNotFound, Message: "InvalidInstanceID.NotFound"},
+				{Category: common.CategoryAccessDenied, Message: "UnauthorizedOperation"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := onlyNotFoundErrors(tt.errors); got != tt.want {
+				t.Errorf("onlyNotFoundErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
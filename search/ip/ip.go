@@ -0,0 +1,464 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ip contains the lookup of the owners of IP addresses.
+//
+// It implements the common.Results interface.
+package ip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dyegoe/awss/common"
+	searchEC2 "github.com/dyegoe/awss/search/ec2"
+	searchENI "github.com/dyegoe/awss/search/eni"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// FilterAddress is the filter key holding the IP addresses and CIDRs to look up.
+	FilterAddress = "address"
+
+	// Resource types reported by the lookup.
+	typeNetworkInterface = "network-interface"
+	typeElasticIP        = "elastic-ip"
+	typeNatGateway       = "nat-gateway"
+	typeLoadBalancer     = "load-balancer"
+
+	// elbDescriptionPrefix is the prefix of the description of the ENIs managed by Elastic Load Balancing.
+	elbDescriptionPrefix = "ELB "
+
+	// ipv4Bits is the length of an IPv4 address in bits.
+	ipv4Bits = 32
+
+	// ipv6Bits is the length of an IPv6 address in bits.
+	ipv6Bits = 128
+)
+
+// Results describes results of the IP addresses lookup.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the owners found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search. Only "address" is used by the lookup.
+	Filters map[string][]string `json:"-"`
+
+	// NoInstanceName skips the instance name lookup when true.
+	NoInstanceName bool `json:"-"`
+}
+
+// dataRow represents an IP address and the resource owning it.
+type dataRow struct {
+	// Address is the IP address or CIDR given to the lookup.
+	Address string `json:"address,omitempty" header:"Address" sort:"address"`
+
+	// IPAddress is the IP address matched on the resource.
+	IPAddress string `json:"ip,omitempty" header:"IP" sort:"ip"`
+
+	// ResourceType is the type of the resource owning the IP address.
+	ResourceType string `json:"resource_type,omitempty" header:"Type" sort:"type"`
+
+	// ResourceID is the ID of the resource owning the IP address.
+	ResourceID string `json:"resource_id,omitempty" header:"Resource ID" sort:"resource-id"`
+
+	// InstanceID is the ID of the instance the resource is attached to.
+	InstanceID string `json:"instance_id,omitempty" header:"Instance ID" sort:"instance-id"`
+
+	// InstanceName is the name of the instance the resource is attached to.
+	InstanceName string `json:"instance_name,omitempty" header:"Instance Name" sort:"instance-name"`
+
+	// Description is the description of the resource.
+	Description string `json:"description,omitempty" header:"Description"`
+
+	// Account is the AWS account ID owning the resource.
+	Account string `json:"account,omitempty" header:"Account" sort:"account"`
+}
+
+// New initiates and returns a new instance of the IP addresses lookup results.
+func New(profile, region string, filters map[string][]string, sortField string, noInstanceName bool) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:           []dataRow{},
+		Filters:        filters,
+		NoInstanceName: noInstanceName,
+	}
+}
+
+// Search performs the IP addresses lookup.
+//
+// It looks for the addresses on ENIs, Elastic IPs and NAT gateways.
+// ENIs managed by Elastic Load Balancing are reported as load balancers.
+func (r *Results) Search(ctx context.Context) {
+	addresses, err := ParseAddresses(r.Filters[FilterAddress])
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+	client := ec2.NewFromConfig(cfg)
+
+	r.searchNetworkInterfaces(ctx, addresses)
+	r.searchElasticIPs(ctx, client, addresses)
+	r.searchNatGateways(ctx, client, addresses)
+	r.enrichInstanceNames(ctx)
+
+	if len(r.Data) > 0 {
+		account, err := common.AccountID(ctx, r.Profile, r.Region)
+		if err != nil {
+			r.AddError(fmt.Errorf("error getting account id: %w", err))
+		}
+		for i := range r.Data {
+			r.Data[i].Account = account
+		}
+	}
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// ParseAddresses parses the IPv4 or IPv6 addresses and CIDRs to look up.
+//
+// A single address is returned as a /32 network, or /128 for IPv6.
+func ParseAddresses(values []string) ([]*net.IPNet, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no address to look up")
+	}
+	addresses := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		if strings.Contains(value, "/") {
+			_, ipNet, err := net.ParseCIDR(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR: %s", value)
+			}
+			addresses = append(addresses, ipNet)
+			continue
+		}
+		ip := net.ParseIP(value)
+		switch {
+		case ip == nil:
+			return nil, fmt.Errorf("invalid IP address: %s", value)
+		case ip.To4() != nil:
+			addresses = append(addresses, &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(ipv4Bits, ipv4Bits)})
+		default:
+			addresses = append(addresses, &net.IPNet{IP: ip, Mask: net.CIDRMask(ipv6Bits, ipv6Bits)})
+		}
+	}
+	return addresses, nil
+}
+
+// isHost returns true if the address is a single IP address, i.e. a /32 or a /128 for IPv6.
+func isHost(address *net.IPNet) bool {
+	ones, bits := address.Mask.Size()
+	return ones == bits
+}
+
+// matchAddress returns the first address containing the given IP, as given to the lookup.
+//
+// It returns an empty string when no address contains the IP.
+func matchAddress(addresses []*net.IPNet, ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	for _, address := range addresses {
+		if !address.Contains(parsed) {
+			continue
+		}
+		if isHost(address) {
+			return address.IP.String()
+		}
+		return address.String()
+	}
+	return ""
+}
+
+// exactIPs returns the addresses as plain IPv4 and IPv6 addresses when none of them is a CIDR wider than a host.
+//
+// The last return value is false when at least one CIDR is given.
+func exactIPs(addresses []*net.IPNet) (v4, v6 []string, ok bool) {
+	for _, address := range addresses {
+		if !isHost(address) {
+			return nil, nil, false
+		}
+		if address.IP.To4() != nil {
+			v4 = append(v4, address.IP.String())
+			continue
+		}
+		v6 = append(v6, address.IP.String())
+	}
+	return v4, v6, true
+}
+
+// searchNetworkInterfaces looks for the addresses on the ENIs.
+//
+// Plain IPs are searched with the ENI filters for private, public and IPv6 addresses.
+// When a CIDR is given, all ENIs are listed and matched locally.
+// The instance names are resolved later by enrichInstanceNames, so the ENI search skips them.
+func (r *Results) searchNetworkInterfaces(ctx context.Context, addresses []*net.IPNet) {
+	searches := []map[string][]string{{}}
+	if v4, v6, ok := exactIPs(addresses); ok {
+		searches = []map[string][]string{}
+		if len(v4) > 0 {
+			searches = append(searches,
+				map[string][]string{"addresses.private-ip-address": v4},
+				map[string][]string{"association.public-ip": v4},
+			)
+		}
+		if len(v6) > 0 {
+			searches = append(searches, map[string][]string{"ipv6-addresses.ipv6-address": v6})
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, filters := range searches {
+		enis := searchENI.New(r.Profile, r.Region, filters, "", true)
+		enis.Search(ctx)
+		r.Errors = append(r.Errors, enis.GetErrors()...)
+
+		for _, row := range enis.Data { //nolint:gocritic
+			if seen[row.InterfaceInfo.NetworkInterfaceID] {
+				continue
+			}
+			seen[row.InterfaceInfo.NetworkInterfaceID] = true
+			r.Data = append(r.Data, networkInterfaceRows(addresses, networkInterface{
+				ID:          row.InterfaceInfo.NetworkInterfaceID,
+				Type:        row.InterfaceInfo.InterfaceType,
+				InstanceID:  row.InterfaceInfo.InstanceID,
+				Description: row.Description,
+				IPs:         slices.Concat(row.PrivateIPAddresses, row.PublicIPAddresses, row.IPv6Addresses),
+			})...)
+		}
+	}
+}
+
+// networkInterface holds the ENI fields used by the lookup.
+type networkInterface struct {
+	ID          string
+	Type        string
+	InstanceID  string
+	Description string
+	IPs         []string
+}
+
+// networkInterfaceRows returns one row per IP address of the ENI matching the addresses.
+//
+// ENIs of NAT gateways are skipped, they are reported by searchNatGateways with the NAT gateway ID.
+// ENIs managed by Elastic Load Balancing are reported with the load balancer name as resource ID.
+// Other requester-managed ENIs are reported with their interface type, e.g. `vpc-endpoint`.
+func networkInterfaceRows(addresses []*net.IPNet, eni networkInterface) []dataRow {
+	if eni.Type == string(types.NetworkInterfaceTypeNatGateway) {
+		return nil
+	}
+
+	resourceType, resourceID := typeNetworkInterface, eni.ID
+	switch {
+	case strings.HasPrefix(eni.Description, elbDescriptionPrefix):
+		resourceType, resourceID = typeLoadBalancer, strings.TrimPrefix(eni.Description, elbDescriptionPrefix)
+	case eni.Type != "" && eni.Type != string(types.NetworkInterfaceTypeInterface):
+		resourceType = strings.ReplaceAll(eni.Type, "_", "-")
+	}
+
+	rows := []dataRow{}
+	for _, ip := range eni.IPs {
+		address := matchAddress(addresses, ip)
+		if address == "" {
+			continue
+		}
+		rows = append(rows, dataRow{
+			Address:      address,
+			IPAddress:    ip,
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			InstanceID:   eni.InstanceID,
+			Description:  eni.Description,
+		})
+	}
+	return rows
+}
+
+// searchElasticIPs looks for the addresses on the Elastic IPs, associated or not.
+func (r *Results) searchElasticIPs(ctx context.Context, client *ec2.Client, addresses []*net.IPNet) {
+	response, err := client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		r.AddError(fmt.Errorf("error describing addresses: %w", err))
+		return
+	}
+	for _, eip := range response.Addresses { //nolint:gocritic
+		for _, ip := range []string{common.StringValue(eip.PublicIp), common.StringValue(eip.PrivateIpAddress)} {
+			address := matchAddress(addresses, ip)
+			if address == "" {
+				continue
+			}
+			r.Data = append(r.Data, dataRow{
+				Address:      address,
+				IPAddress:    ip,
+				ResourceType: typeElasticIP,
+				ResourceID:   common.StringValue(eip.AllocationId),
+				InstanceID:   common.StringValue(eip.InstanceId),
+				Description:  common.TagName(eip.Tags),
+			})
+		}
+	}
+}
+
+// searchNatGateways looks for the addresses on the NAT gateways.
+func (r *Results) searchNatGateways(ctx context.Context, client *ec2.Client, addresses []*net.IPNet) {
+	paginator := ec2.NewDescribeNatGatewaysPaginator(client, &ec2.DescribeNatGatewaysInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.AddError(fmt.Errorf("error describing nat gateways: %w", err))
+			return
+		}
+		for _, nat := range page.NatGateways { //nolint:gocritic
+			for _, natAddress := range nat.NatGatewayAddresses {
+				ips := []string{common.StringValue(natAddress.PrivateIp), common.StringValue(natAddress.PublicIp)}
+				for _, ip := range ips {
+					address := matchAddress(addresses, ip)
+					if address == "" {
+						continue
+					}
+					r.Data = append(r.Data, dataRow{
+						Address:      address,
+						IPAddress:    ip,
+						ResourceType: typeNatGateway,
+						ResourceID:   common.StringValue(nat.NatGatewayId),
+						Description:  common.TagName(nat.Tags),
+					})
+				}
+			}
+		}
+	}
+}
+
+// enrichInstanceNames fills the instance names with a single lookup for all the instances found.
+func (r *Results) enrichInstanceNames(ctx context.Context) {
+	if r.NoInstanceName {
+		return
+	}
+	instanceIDs := []string{}
+	for i := range r.Data {
+		if id := r.Data[i].InstanceID; id != "" && !common.StringInSlice(id, instanceIDs) {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	if len(instanceIDs) == 0 {
+		return
+	}
+	names, err := searchEC2.SearchInstanceNames(ctx, r.Profile, r.Region, instanceIDs)
+	if err != nil {
+		r.AddError(err)
+		return
+	}
+	for i := range r.Data {
+		r.Data[i].InstanceName = names[r.Data[i].InstanceID]
+	}
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(r.Data, func(p, q int) bool {
+		sortField1 := reflect.ValueOf(r.Data[p]).FieldByName(sortFields[field]).String()
+		sortField2 := reflect.ValueOf(r.Data[q]).FieldByName(sortFields[field]).String()
+		return sortField1 < sortField2
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
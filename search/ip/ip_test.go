@@ -0,0 +1,239 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ip contains the lookup of the owners of IP addresses.
+//
+// It implements the common.Results interface.
+package ip
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// mustParseAddresses parses the addresses or fails the test.
+func mustParseAddresses(t *testing.T, values ...string) []*net.IPNet {
+	t.Helper()
+	addresses, err := ParseAddresses(values)
+	if err != nil {
+		t.Fatalf("ParseAddresses(%v) unexpected error: %v", values, err)
+	}
+	return addresses
+}
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{FilterAddress: {"10.0.0.1"}}, "address", true)
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "address",
+		},
+		Data:           []dataRow{},
+		Filters:        map[string][]string{FilterAddress: {"10.0.0.1"}},
+		NoInstanceName: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	want := []interface{}{
+		"Address", "IP", "Type", "Resource ID", "Instance ID", "Instance Name", "Description", "Account",
+	}
+	if got := New("", "", nil, "", false).GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestParseAddresses tests the ParseAddresses function.
+func TestParseAddresses(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "single address", values: []string{"10.0.0.1"}, want: []string{"10.0.0.1/32"}},
+		{name: "cidr", values: []string{"10.0.0.17/24"}, want: []string{"10.0.0.0/24"}},
+		{name: "mixed", values: []string{"54.1.2.3", "172.16.0.0/12"}, want: []string{"54.1.2.3/32", "172.16.0.0/12"}},
+		{name: "empty", values: []string{}, wantErr: true},
+		{name: "invalid address", values: []string{"10.0.0.256"}, wantErr: true},
+		{name: "invalid cidr", values: []string{"10.0.0.0/33"}, wantErr: true},
+		{name: "ipv6 address", values: []string{"2600:1f18::1"}, want: []string{"2600:1f18::1/128"}},
+		{name: "ipv6 cidr", values: []string{"2600:1f18::17/56"}, want: []string{"2600:1f18::/56"}},
+		{name: "invalid ipv6 address", values: []string{"2600:1f18::g"}, wantErr: true},
+		{name: "invalid ipv6 cidr", values: []string{"2600:1f18::/129"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAddresses(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAddresses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotStrings := make([]string, 0, len(got))
+			for _, address := range got {
+				gotStrings = append(gotStrings, address.String())
+			}
+			if !reflect.DeepEqual(gotStrings, tt.want) {
+				t.Errorf("ParseAddresses()\n%#v\nwant\n%#v", gotStrings, tt.want)
+			}
+		})
+	}
+}
+
+// TestMatchAddress tests the matchAddress function.
+func TestMatchAddress(t *testing.T) {
+	addresses := mustParseAddresses(t, "10.0.0.1", "172.16.0.0/12", "2600:1f18::1", "2600:1f18:100::/56")
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "exact address", ip: "10.0.0.1", want: "10.0.0.1"},
+		{name: "inside cidr", ip: "172.20.1.1", want: "172.16.0.0/12"},
+		{name: "no match", ip: "10.0.0.2", want: ""},
+		{name: "exact ipv6 address", ip: "2600:1f18::1", want: "2600:1f18::1"},
+		{name: "inside ipv6 cidr", ip: "2600:1f18:100::10", want: "2600:1f18:100::/56"},
+		{name: "no ipv6 match", ip: "2600:1f18::2", want: ""},
+		{name: "empty ip", ip: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchAddress(addresses, tt.ip); got != tt.want {
+				t.Errorf("matchAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExactIPs tests the exactIPs function.
+func TestExactIPs(t *testing.T) {
+	v4, v6, ok := exactIPs(mustParseAddresses(t, "10.0.0.1", "2600:1f18::1", "54.1.2.3"))
+	wantV4, wantV6 := []string{"10.0.0.1", "54.1.2.3"}, []string{"2600:1f18::1"}
+	if !ok || !reflect.DeepEqual(v4, wantV4) || !reflect.DeepEqual(v6, wantV6) {
+		t.Errorf("exactIPs() = %v, %v, %v, want [10.0.0.1 54.1.2.3], [2600:1f18::1], true", v4, v6, ok)
+	}
+	if _, _, ok := exactIPs(mustParseAddresses(t, "10.0.0.1", "10.0.0.0/24")); ok {
+		t.Error("exactIPs() with a cidr = true, want false")
+	}
+	if _, _, ok := exactIPs(mustParseAddresses(t, "2600:1f18::/56")); ok {
+		t.Error("exactIPs() with an ipv6 cidr = true, want false")
+	}
+}
+
+// TestNetworkInterfaceRows tests the networkInterfaceRows function.
+func TestNetworkInterfaceRows(t *testing.T) {
+	addresses := mustParseAddresses(t, "10.0.0.0/24")
+	tests := []struct {
+		name string
+		eni  networkInterface
+		want []dataRow
+	}{
+		{
+			name: "instance interface",
+			eni: networkInterface{
+				ID: "eni-1", Type: "interface", InstanceID: "i-1",
+				IPs: []string{"10.0.0.10", "10.0.1.10", "54.1.2.3"},
+			},
+			want: []dataRow{{
+				Address: "10.0.0.0/24", IPAddress: "10.0.0.10",
+				ResourceType: "network-interface", ResourceID: "eni-1", InstanceID: "i-1",
+			}},
+		},
+		{
+			name: "load balancer interface",
+			eni: networkInterface{
+				ID: "eni-2", Type: "interface", Description: "ELB app/web/50dc6c495c0c9188",
+				IPs: []string{"10.0.0.20"},
+			},
+			want: []dataRow{{
+				Address: "10.0.0.0/24", IPAddress: "10.0.0.20",
+				ResourceType: "load-balancer", ResourceID: "app/web/50dc6c495c0c9188",
+				Description: "ELB app/web/50dc6c495c0c9188",
+			}},
+		},
+		{
+			name: "requester managed interface",
+			eni: networkInterface{
+				ID: "eni-3", Type: "vpc_endpoint", Description: "VPC Endpoint Interface vpce-1",
+				IPs: []string{"10.0.0.30"},
+			},
+			want: []dataRow{{
+				Address: "10.0.0.0/24", IPAddress: "10.0.0.30",
+				ResourceType: "vpc-endpoint", ResourceID: "eni-3",
+				Description: "VPC Endpoint Interface vpce-1",
+			}},
+		},
+		{
+			name: "nat gateway interface is skipped",
+			eni:  networkInterface{ID: "eni-4", Type: "natGateway", IPs: []string{"10.0.0.40"}},
+			want: nil,
+		},
+		{
+			name: "no match",
+			eni:  networkInterface{ID: "eni-5", Type: "interface", IPs: []string{"10.0.1.50"}},
+			want: []dataRow{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := networkInterfaceRows(addresses, tt.eni); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("networkInterfaceRows()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	r := New("", "", nil, "", false)
+	r.Data = []dataRow{
+		{Address: "10.0.0.2", ResourceType: "elastic-ip"},
+		{Address: "10.0.0.1", ResourceType: "nat-gateway"},
+	}
+	if err := r.sortResults("address"); err != nil {
+		t.Fatalf("sortResults(address) unexpected error: %v", err)
+	}
+	if r.Data[0].Address != "10.0.0.1" {
+		t.Errorf("sortResults(address) first row = %s, want 10.0.0.1", r.Data[0].Address)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	for _, f := range []string{"address", "ip", "type", "resource-id", "instance-id", "instance-name", "account"} {
+		if _, err := GetSortFields(f); err != nil {
+			t.Errorf("GetSortFields(%q) unexpected error: %v", f, err)
+		}
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ip
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// SearchName is the search name of the IP addresses lookup.
+const SearchName = "ip"
+
+// The IP addresses lookup is registered when the package is imported.
+// Its command takes the addresses as arguments, so it is defined in the cmd package.
+var _ = search.Register(search.Resource{
+	Name:          SearchName,
+	New:           search.WithInstanceName(New),
+	GetSortFields: GetSortFields,
+})
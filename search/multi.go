@@ -0,0 +1,214 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// FilterID is the shared filter key holding resource IDs in a multi-resource search.
+//
+// Each ID is passed to the resource whose ID prefixes match it, under the resource IDFilter.
+const FilterID = "id"
+
+// TypeSearch is the search of one resource type in a multi-resource search.
+type TypeSearch struct {
+	// Name is the resource name.
+	Name string
+
+	// Filters are the filters of the resource, built from the shared filters by PlanSearches.
+	Filters map[string][]string
+
+	// SortField is the field used to sort the results of the resource.
+	SortField string
+}
+
+// typeSetter is implemented by the results embedding common.BaseResults.
+type typeSetter interface {
+	SetType(t string)
+}
+
+// MultiTypes returns the sorted names of the resources that can be used in a multi-resource search.
+//
+// They are the resources with a command and ID prefixes.
+func MultiTypes() []string {
+	names := []string{}
+	for _, r := range Resources() { //nolint:gocritic
+		if r.Command != nil && len(r.IDPrefixes) > 0 {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// PlanSearches returns the search of each given type with its filters built from the shared filters.
+//
+// Duplicated types are searched once.
+// The "id" filter is split by ID prefix, and the types without any matching ID are left out.
+// The other shared filters are passed as-is to every type.
+// It returns an error if a type is unknown, if a type does not support one of the shared filters,
+// or if an ID does not match any of the types.
+func PlanSearches(types []string, shared map[string][]string) ([]TypeSearch, error) {
+	searches := []TypeSearch{}
+	matchedIDs := map[string]bool{}
+	seen := map[string]bool{}
+
+	for _, name := range types {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		resource, err := lookup(name)
+		if err != nil || !common.StringInSlice(name, MultiTypes()) {
+			return nil, fmt.Errorf("invalid type: %s. The options are: %s",
+				name, common.StringSliceToString(MultiTypes(), ", "))
+		}
+
+		filters := map[string][]string{}
+		for key, values := range shared {
+			if key == FilterID {
+				continue
+			}
+			if !supportsFilter(resource, key) {
+				return nil, fmt.Errorf("type %s does not support the %s filter", name, key)
+			}
+			filters[key] = values
+		}
+
+		if ids, ok := shared[FilterID]; ok {
+			resourceIDs := idsWithPrefix(ids, resource.IDPrefixes)
+			if len(resourceIDs) == 0 {
+				continue
+			}
+			for _, id := range resourceIDs {
+				matchedIDs[id] = true
+			}
+			filters[resource.IDFilter] = resourceIDs
+		}
+
+		searches = append(searches, TypeSearch{Name: name, Filters: filters})
+	}
+
+	for _, id := range shared[FilterID] {
+		if !matchedIDs[id] {
+			return nil, fmt.Errorf("id %s does not match any of the types: %s",
+				id, common.StringSliceToString(types, ", "))
+		}
+	}
+	return searches, nil
+}
+
+// supportsFilter returns true if the filter struct of the resource has a field with the given filter key.
+func supportsFilter(resource Resource, key string) bool { //nolint:gocritic
+	if resource.Filters == nil {
+		return false
+	}
+	v := reflect.Indirect(reflect.ValueOf(resource.Filters))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("filter") == key {
+			return true
+		}
+	}
+	return false
+}
+
+// idsWithPrefix returns the IDs starting with one of the given prefixes.
+func idsWithPrefix(ids, prefixes []string) []string {
+	matched := []string{}
+	for _, id := range ids {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(id, prefix) {
+				matched = append(matched, id)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// ExecuteMany executes several resource searches in the given profiles and regions.
+//
+// The searches run in parallel, then the results are printed grouped by type,
+// in the order of the searches, profiles and regions, unless stream is set, see runSearches.
+// Each result shows its type.
+// The searches are stopped when the context is done.
+// It returns the summary of the searches, see Summary.
+func ExecuteMany(
+	ctx context.Context,
+	searches []TypeSearch,
+	profiles, regions []string,
+	output string,
+	showEmpty, showTags, noInstanceName, stream bool,
+) (Summary, error) {
+	newFuncs := make([]profileRegionFunc, 0, len(searches))
+	for _, s := range searches {
+		resource, err := lookup(s.Name)
+		if err != nil {
+			return Summary{}, err
+		}
+		newFuncs = append(newFuncs, func(profile, region string) common.Results {
+			results := resource.New(profile, region, s.Filters, s.SortField, noInstanceName)
+			if t, ok := results.(typeSetter); ok {
+				t.SetType(s.Name)
+			}
+			return results
+		})
+	}
+
+	return runSearches(
+		ctx,
+		searches,
+		newFuncs,
+		profiles, regions,
+		output,
+		showEmpty, showTags, stream,
+	)
+}
+
+// groupResults returns the results ordered by type, profile and region, following the given order.
+func groupResults(results []common.Results, searches []TypeSearch, profiles, regions []string) []common.Results {
+	byKey := make(map[string]common.Results, len(results))
+	for _, r := range results {
+		byKey[groupKey(r.GetType(), r.GetProfile(), r.GetRegion())] = r
+	}
+
+	grouped := make([]common.Results, 0, len(results))
+	for _, s := range searches {
+		for _, profile := range profiles {
+			for _, region := range regions {
+				if r, ok := byKey[groupKey(s.Name, profile, region)]; ok {
+					grouped = append(grouped, r)
+				}
+			}
+		}
+	}
+	return grouped
+}
+
+// groupKey returns the key of a result in groupResults.
+func groupKey(resourceType, profile, region string) string {
+	return resourceType + "/" + profile + "/" + region
+}
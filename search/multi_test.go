@@ -0,0 +1,156 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// mockFilters is a filter struct used to test the shared filters support.
+type mockFilters struct {
+	Ids               []string `filter:"instance-id"`
+	Tags              []string `filter:"tag"`
+	AvailabilityZones []string `filter:"availability-zone"`
+}
+
+// mockMultiRegistry returns a registry used to test the multi-resource search.
+func mockMultiRegistry() map[string]Resource {
+	return map[string]Resource{
+		"ec2": {
+			Name: "ec2", Filters: &mockFilters{}, Command: &Command{},
+			IDPrefixes: []string{"i-"}, IDFilter: "instance-id",
+		},
+		"vpc": {
+			Name: "vpc", Filters: &struct {
+				Tags []string `filter:"tag"`
+			}{}, Command: &Command{},
+			IDPrefixes: []string{"vpc-"}, IDFilter: "vpc-id",
+		},
+		"ip": {Name: "ip"},
+	}
+}
+
+// TestMultiTypes tests the MultiTypes function.
+func TestMultiTypes(t *testing.T) {
+	oldRegistry := registry
+	defer func() { registry = oldRegistry }()
+	registry = mockMultiRegistry()
+
+	want := []string{"ec2", "vpc"}
+	if got := MultiTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MultiTypes()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestPlanSearches tests the PlanSearches function.
+func TestPlanSearches(t *testing.T) {
+	oldRegistry := registry
+	defer func() { registry = oldRegistry }()
+	registry = mockMultiRegistry()
+
+	tests := []struct {
+		name    string
+		types   []string
+		shared  map[string][]string
+		want    []TypeSearch
+		wantErr bool
+	}{
+		{
+			name:   "shared tags",
+			types:  []string{"vpc", "ec2", "vpc"},
+			shared: map[string][]string{"tag": {"Project=foo"}},
+			want: []TypeSearch{
+				{Name: "vpc", Filters: map[string][]string{"tag": {"Project=foo"}}},
+				{Name: "ec2", Filters: map[string][]string{"tag": {"Project=foo"}}},
+			},
+		},
+		{
+			name:   "ids split by prefix",
+			types:  []string{"ec2", "vpc"},
+			shared: map[string][]string{FilterID: {"i-1", "vpc-1", "i-2"}},
+			want: []TypeSearch{
+				{Name: "ec2", Filters: map[string][]string{"instance-id": {"i-1", "i-2"}}},
+				{Name: "vpc", Filters: map[string][]string{"vpc-id": {"vpc-1"}}},
+			},
+		},
+		{
+			name:   "types without matching ids are left out",
+			types:  []string{"ec2", "vpc"},
+			shared: map[string][]string{FilterID: {"i-1"}},
+			want:   []TypeSearch{{Name: "ec2", Filters: map[string][]string{"instance-id": {"i-1"}}}},
+		},
+		{
+			name:   "all",
+			types:  []string{"ec2"},
+			shared: map[string][]string{},
+			want:   []TypeSearch{{Name: "ec2", Filters: map[string][]string{}}},
+		},
+		{
+			name:    "unsupported filter",
+			types:   []string{"ec2", "vpc"},
+			shared:  map[string][]string{"availability-zone": {"a"}},
+			wantErr: true,
+		},
+		{
+			name:    "id matching no type",
+			types:   []string{"ec2"},
+			shared:  map[string][]string{FilterID: {"i-1", "vpc-1"}},
+			wantErr: true,
+		},
+		{name: "unknown type", types: []string{"foo"}, wantErr: true},
+		{name: "type without command", types: []string{"ip"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanSearches(tt.types, tt.shared)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanSearches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanSearches()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGroupResults tests the groupResults function.
+func TestGroupResults(t *testing.T) {
+	newResults := func(resourceType, profile, region string) common.Results {
+		return &mockResults{BaseResults: common.BaseResults{Type: resourceType, Profile: profile, Region: region}}
+	}
+	results := []common.Results{
+		newResults("eni", "prod", "us-east-1"),
+		newResults("ec2", "dev", "eu-west-1"),
+		newResults("ec2", "prod", "us-east-1"),
+		newResults("eni", "dev", "us-east-1"),
+	}
+	searches := []TypeSearch{{Name: "ec2"}, {Name: "eni"}}
+	profiles := []string{"prod", "dev"}
+	regions := []string{"us-east-1", "eu-west-1"}
+
+	want := []string{"ec2/prod/us-east-1", "ec2/dev/eu-west-1", "eni/prod/us-east-1", "eni/dev/us-east-1"}
+	got := []string{}
+	for _, r := range groupResults(results, searches, profiles, regions) {
+		got = append(got, groupKey(r.GetType(), r.GetProfile(), r.GetRegion()))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupResults()\n%#v\nwant\n%#v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package search provides the entry point for the search command.
+//
+// It implements a search command that searches for resources in AWS.
+// The searches are done in parallel and the results are printed in the
+// specified format.
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dyegoe/awss/common"
+)
+
+const (
+	// AuthErrorFail stops before the searches if the credentials of a profile are invalid.
+	AuthErrorFail = "fail"
+	// AuthErrorSkip skips the searches of the profiles with invalid credentials, their results have the error.
+	AuthErrorSkip = "skip"
+)
+
+// onAuthError is the policy for the profiles with invalid credentials, see SetOnAuthError.
+var onAuthError = AuthErrorFail
+
+// SetOnAuthError sets the policy for the profiles with invalid credentials, AuthErrorFail or AuthErrorSkip.
+func SetOnAuthError(policy string) {
+	onAuthError = policy
+}
+
+// preAuth returns the identity of a profile, used to check its credentials before the searches, see preflight.
+//
+// We use this var to allow tests to mock the function.
+var preAuth = common.CallerIdentity
+
+// stderr is the writer of the credential status table, see checkCredentials.
+//
+// We use this var to allow tests to mock the writer.
+var stderr io.Writer = os.Stderr
+
+// preflight checks the credentials of each distinct profile in parallel.
+//
+// Each profile is checked in its own region, or in common.DefaultIdentityRegion if it has none,
+// not in the searched regions, which may be disabled or of another partition.
+// The credentials are returned in the order of the profiles.
+func preflight(ctx context.Context, profiles []string) []common.Credentials {
+	credentials := []common.Credentials{}
+	seen := map[string]bool{}
+	for _, profile := range profiles {
+		if !seen[profile] {
+			seen[profile] = true
+			credentials = append(credentials, common.Credentials{Profile: profile})
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range credentials {
+		wg.Add(1)
+
+		go func(c *common.Credentials) {
+			defer wg.Done()
+
+			c.Identity, c.Err = preAuth(ctx, c.Profile, "")
+		}(&credentials[i])
+	}
+	wg.Wait()
+
+	return credentials
+}
+
+// checkCredentials checks the credentials of the profiles before the searches, see preflight.
+//
+// The credential status table is printed to stderr when there are several profiles or a check failed.
+// It returns the errors of the profiles with invalid credentials, to skip their searches,
+// or an error if a check failed and the policy is AuthErrorFail.
+func checkCredentials(ctx context.Context, profiles []string) (map[string]error, error) {
+	credentials := preflight(ctx, profiles)
+
+	failed := map[string]error{}
+	names := []string{}
+	for _, c := range credentials {
+		if c.Err != nil {
+			failed[c.Profile] = c.Err
+			names = append(names, c.Profile)
+		}
+	}
+
+	if len(credentials) > 1 || len(failed) > 0 {
+		fmt.Fprintln(stderr, common.CredentialsTable(credentials))
+	}
+
+	if len(failed) > 0 && onAuthError != AuthErrorSkip {
+		return nil, fmt.Errorf("invalid credentials for the profiles: %s", strings.Join(names, ", "))
+	}
+	return failed, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// mockPreAuth returns the identity of the profiles, except the "expired" profile which returns an error.
+func mockPreAuth(_ context.Context, profile, _ string) (common.Identity, error) {
+	if profile == "expired" {
+		return common.Identity{}, errors.New("token expired")
+	}
+	return common.Identity{Account: "123456789012", ARN: "arn:aws:iam::123456789012:user/" + profile}, nil
+}
+
+// TestCheckCredentials tests the checkCredentials function.
+func TestCheckCredentials(t *testing.T) {
+	// save the original function, writer and policy, defer the restore and mock them
+	oldPreAuth := preAuth
+	oldStderr := stderr
+	oldOnAuthError := onAuthError
+	defer func() {
+		preAuth = oldPreAuth
+		stderr = oldStderr
+		onAuthError = oldOnAuthError
+	}()
+	preAuth = mockPreAuth
+
+	tests := []struct {
+		name       string
+		profiles   []string
+		policy     string
+		wantFailed []string
+		wantErr    bool
+		wantTable  bool
+	}{
+		{name: "one valid profile", profiles: []string{"dev"}, policy: AuthErrorFail, wantFailed: []string{}},
+		{
+			name: "several valid profiles", profiles: []string{"dev", "prod", "dev"}, policy: AuthErrorFail,
+			wantFailed: []string{}, wantTable: true,
+		},
+		{name: "fail policy", profiles: []string{"dev", "expired"}, policy: AuthErrorFail, wantErr: true, wantTable: true},
+		{
+			name: "skip policy", profiles: []string{"dev", "expired"}, policy: AuthErrorSkip,
+			wantFailed: []string{"expired"}, wantTable: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			stderr = &buffer
+			SetOnAuthError(tt.policy)
+
+			failed, err := checkCredentials(context.Background(), tt.profiles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotTable := buffer.Len() > 0; gotTable != tt.wantTable {
+				t.Errorf("checkCredentials() printed the table %v, want %v", gotTable, tt.wantTable)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := []string{}
+			for profile := range failed {
+				got = append(got, profile)
+			}
+			if !reflect.DeepEqual(got, tt.wantFailed) {
+				t.Errorf("checkCredentials()\n%#v\nwant\n%#v", got, tt.wantFailed)
+			}
+		})
+	}
+}
+
+// TestPreflight tests the preflight function.
+func TestPreflight(t *testing.T) {
+	// save the original function, defer the restore and mock the function
+	oldPreAuth := preAuth
+	defer func() { preAuth = oldPreAuth }()
+	preAuth = func(ctx context.Context, profile, region string) (common.Identity, error) {
+		if region != "" {
+			t.Errorf("preflight() checked %s in the region %q, want the region of the profile", profile, region)
+		}
+		return mockPreAuth(ctx, profile, region)
+	}
+
+	got := preflight(context.Background(), []string{"dev", "expired", "dev"})
+
+	want := []common.Credentials{
+		{Profile: "dev", Identity: common.Identity{Account: "123456789012", ARN: "arn:aws:iam::123456789012:user/dev"}},
+		{Profile: "expired", Err: errors.New("token expired")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("preflight()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestFanOut_skip tests that fanOut does not search the profiles skipped by the credentials check.
+func TestFanOut_skip(t *testing.T) {
+	// save the original function, writer and policy, defer the restore and mock them
+	oldPreAuth := preAuth
+	oldStderr := stderr
+	oldOnAuthError := onAuthError
+	defer func() {
+		preAuth = oldPreAuth
+		stderr = oldStderr
+		onAuthError = oldOnAuthError
+	}()
+	preAuth = mockPreAuth
+	stderr = &bytes.Buffer{}
+	SetOnAuthError(AuthErrorSkip)
+
+	newResults := func(profile, region string) common.Results {
+		return &deadlineResults{mockResults: mockResults{BaseResults: common.BaseResults{Profile: profile, Region: region}}}
+	}
+	resultsChan := make(chan common.Results, 2)
+
+	all, err := fanOut(context.Background(), []profileRegionFunc{newResults}, []string{"dev", "expired"}, []string{"r"},
+		resultsChan)
+	if err != nil {
+		t.Fatalf("fanOut() unexpected error: %v", err)
+	}
+
+	for _, r := range all {
+		searched := r.(*deadlineResults).searched
+		errs := r.GetErrors()
+		switch r.GetProfile() {
+		case "dev":
+			if !searched || len(errs) > 0 {
+				t.Errorf("fanOut() dev searched %v with errors %v, want searched without errors", searched, errs)
+			}
+		case "expired":
+			if searched || len(errs) != 1 || !strings.Contains(errs[0].Message, "token expired") {
+				t.Errorf("fanOut() expired searched %v with errors %v, want skipped with the credentials error",
+					searched, errs)
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// NewResultsFunc initiates the results of a search for the given profile and region.
+type NewResultsFunc func(
+	profile, region string, filters map[string][]string, sortField string, noInstanceName bool,
+) common.Results
+
+// Resource describes a searchable resource type.
+//
+// Resource packages register themselves with Register when they are imported.
+type Resource struct {
+	// Name is the search name. It is also the command name and the config key of the command flags.
+	Name string
+
+	// New initiates the results for a profile and region.
+	New NewResultsFunc
+
+	// GetSortFields returns the sort fields, or an error if the given field is not one of them.
+	GetSortFields func(string) (map[string]string, error)
+
+	// Filters is a pointer to the filter struct bound to the command flags.
+	// common.StructToFilters converts it to a map[string][]string.
+	Filters interface{}
+
+	// IDPrefixes are the resource ID prefixes, including the dash, used by the find command.
+	IDPrefixes []string
+
+	// IDFilter is the filter key holding the resource ID, used by the find command.
+	IDFilter string
+
+	// Command is the CLI command metadata. Resources without it have a hand-written command, if any.
+	Command *Command
+}
+
+// Command describes the CLI command of a resource.
+//
+// The cmd package builds a Cobra command from it, with the filter flags, --all, --sort
+// and, when NoInstanceName is true, --no-instance-name.
+type Command struct {
+	// Short is the short description shown in the help.
+	Short string
+
+	// Long is the long description shown in the help.
+	Long string
+
+	// Noun is the plural name of the resource used in the generated flags help, e.g. "EC2 instances".
+	Noun string
+
+	// Flags are the filter flags.
+	Flags []Flag
+
+	// DefaultSort is the default value of the --sort flag.
+	DefaultSort string
+
+	// NoInstanceName adds the --no-instance-name flag.
+	NoInstanceName bool
+}
+
+// Flag describes a filter flag of a resource command.
+type Flag struct {
+	// Name is the flag name.
+	Name string
+
+	// Shorthand is the one-letter abbreviation of the flag.
+	Shorthand string
+
+	// Usage is the help message. A name in backticks is used as the value placeholder.
+	Usage string
+
+	// Value is a pointer to the filter struct field bound to the flag: *[]string, *[]int or *[]net.IP.
+	Value interface{}
+}
+
+// registry is the map of the registered resources.
+//
+// The key is the resource name.
+// We use a variable to mock it in the tests.
+var registry = map[string]Resource{}
+
+// Register adds a resource to the registry.
+//
+// It is meant to be called from a package-level variable declaration, e.g. `var _ = search.Register(...)`.
+// It panics if a resource with the same name is already registered.
+func Register(r Resource) bool {
+	if _, ok := registry[r.Name]; ok {
+		panic(fmt.Sprintf("search: resource %s registered twice", r.Name))
+	}
+	registry[r.Name] = r
+	return true
+}
+
+// Resources returns the registered resources sorted by name.
+func Resources() []Resource {
+	resources := make([]Resource, 0, len(registry))
+	for _, r := range registry { //nolint:gocritic
+		resources = append(resources, r)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources
+}
+
+// lookup returns the registered resource with the given name.
+func lookup(name string) (Resource, error) {
+	r, ok := registry[name]
+	if !ok {
+		return Resource{}, fmt.Errorf("command %s not found", name)
+	}
+	return r, nil
+}
+
+// WithInstanceName adapts a constructor accepting the noInstanceName argument to NewResultsFunc.
+func WithInstanceName[T common.Results](
+	newFunc func(string, string, map[string][]string, string, bool) T,
+) NewResultsFunc {
+	return func(
+		profile, region string, filters map[string][]string, sortField string, noInstanceName bool,
+	) common.Results {
+		return newFunc(profile, region, filters, sortField, noInstanceName)
+	}
+}
+
+// WithoutInstanceName adapts a constructor without the noInstanceName argument to NewResultsFunc.
+func WithoutInstanceName[T common.Results](newFunc func(string, string, map[string][]string, string) T) NewResultsFunc {
+	return func(profile, region string, filters map[string][]string, sortField string, _ bool) common.Results {
+		return newFunc(profile, region, filters, sortField)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// mockResults is a minimal common.Results used to test the constructor adapters.
+type mockResults struct {
+	common.BaseResults
+	noInstanceName bool
+}
+
+func (m *mockResults) Search(_ context.Context)  {}
+func (m *mockResults) Len() int                  { return 0 }
+func (m *mockResults) GetHeaders() []interface{} { return nil }
+func (m *mockResults) GetRows() []interface{}    { return nil }
+
+// TestRegister tests the Register and Resources functions.
+func TestRegister(t *testing.T) {
+	oldRegistry := registry
+	defer func() { registry = oldRegistry }()
+	registry = map[string]Resource{}
+
+	Register(Resource{Name: "vpc"})
+	Register(Resource{Name: "ec2"})
+
+	got := []string{}
+	for _, r := range Resources() { //nolint:gocritic
+		got = append(got, r.Name)
+	}
+	if want := []string{"ec2", "vpc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Resources()\n%#v\nwant\n%#v", got, want)
+	}
+
+	if _, err := lookup("ec2"); err != nil {
+		t.Errorf("lookup(ec2) unexpected error: %v", err)
+	}
+	if _, err := lookup("invalid"); err == nil {
+		t.Error("lookup(invalid) expected error, got nil")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() with a duplicated name expected panic")
+		}
+	}()
+	Register(Resource{Name: "ec2"})
+}
+
+// TestWithInstanceName tests the WithInstanceName and WithoutInstanceName adapters.
+func TestWithInstanceName(t *testing.T) {
+	withFunc := WithInstanceName(func(profile, region string, _ map[string][]string, sortField string, n bool,
+	) *mockResults {
+		base := common.BaseResults{Profile: profile, Region: region, SortField: sortField}
+		return &mockResults{BaseResults: base, noInstanceName: n}
+	})
+	got := withFunc("default", "us-east-1", nil, "id", true).(*mockResults)
+	want := &mockResults{
+		BaseResults:    common.BaseResults{Profile: "default", Region: "us-east-1", SortField: "id"},
+		noInstanceName: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithInstanceName()\n%#v\nwant\n%#v", got, want)
+	}
+
+	withoutFunc := WithoutInstanceName(func(profile, region string, _ map[string][]string, sortField string) *mockResults {
+		return &mockResults{BaseResults: common.BaseResults{Profile: profile, Region: region, SortField: sortField}}
+	})
+	got = withoutFunc("default", "us-east-1", nil, "id", true).(*mockResults)
+	want.noInstanceName = false
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithoutInstanceName()\n%#v\nwant\n%#v", got, want)
+	}
+}
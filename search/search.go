@@ -24,13 +24,12 @@ package search
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/dyegoe/awss/common"
-	searchEBS "github.com/dyegoe/awss/search/ebs"
-	searchEC2 "github.com/dyegoe/awss/search/ec2"
-	searchENI "github.com/dyegoe/awss/search/eni"
 )
 
 // Execute executes the search command.
@@ -39,87 +38,257 @@ import (
 // The filters are used to filter the results.
 // The output is the format of the output.
 // The showEmpty flag indicates if empty results should be shown.
-func Execute(cmd string, profiles, regions []string, filters map[string][]string, sortField, output string, showEmpty, showTags, noInstanceName bool) error { //nolint:lll
-	ctx := context.Background()
-	wg := sync.WaitGroup{}
+// The stream flag indicates if the results should be printed as soon as each search is done, see runSearches.
+// The searches are stopped when the context is done, see searchAll.
+// It returns the summary of the searches, see Summary.
+func Execute(ctx context.Context, cmd string, profiles, regions []string, filters map[string][]string, sortField, output string, showEmpty, showTags, noInstanceName, stream bool) (Summary, error) { //nolint:lll
+	resource, err := lookup(cmd)
+	if err != nil {
+		return Summary{}, err
+	}
 
-	numInteractions := len(profiles) * len(regions)
+	newResults := func(profile, region string) common.Results {
+		return resource.New(profile, region, filters, sortField, noInstanceName)
+	}
 
-	resultsChan := make(chan common.Results, numInteractions)
+	// The results of a single search have no type.
+	return runSearches(
+		ctx,
+		[]TypeSearch{{}},
+		[]profileRegionFunc{newResults},
+		profiles, regions,
+		output,
+		showEmpty, showTags, stream,
+	)
+}
 
-	done := make(chan bool)
+// stdout is the writer of the results.
+//
+// We use this var to allow tests to mock the writer.
+var stdout io.Writer = os.Stdout
+
+// runSearches runs the searches in all the given profiles and regions and prints the results.
+//
+// The searches and newFuncs are in the same order, one function per search.
+// With stream, each result is printed as soon as its search is done.
+// Otherwise, the results are printed once all the searches are done,
+// in the order of the searches, profiles and regions, see groupResults.
+// If the context is done, the results completed so far are printed and an error is returned.
+// It returns the summary of the searches, see summarize.
+func runSearches(
+	ctx context.Context,
+	searches []TypeSearch,
+	newFuncs []profileRegionFunc,
+	profiles, regions []string,
+	output string,
+	showEmpty, showTags, stream bool,
+) (Summary, error) {
+	resultsChan := make(chan common.Results, len(newFuncs)*len(profiles)*len(regions))
+
+	if stream {
+		done := make(chan bool)
+		go common.PrintResults(stdout, resultsChan, done, output, showEmpty, showTags)
+
+		all, err := fanOut(ctx, newFuncs, profiles, regions, resultsChan)
 
-	go common.PrintResults(os.Stdout, resultsChan, done, output, showEmpty, showTags)
+		close(resultsChan)
+		<-done
+		close(done)
 
-	runOnce := true
+		if err != nil {
+			return Summary{}, err
+		}
+		return summarize(all), stopped(ctx)
+	}
+
+	all, err := fanOut(ctx, newFuncs, profiles, regions, resultsChan)
+	if err != nil {
+		return Summary{}, err
+	}
+	close(resultsChan)
 
-	for _, profile := range profiles {
-		for _, region := range regions {
-			var searchResults common.Results
+	printResults(groupResults(all, searches, profiles, regions), output, showEmpty, showTags)
 
-			// Workaround to avoid to spam Okta with too many requests.
-			// It will run once just to pre-authenticate.
-			if runOnce {
-				if _, err := common.WhoAmI(profile, region); err != nil {
-					return err
+	return summarize(all), stopped(ctx)
+}
+
+// stopped returns an error if the searches were stopped by the context, on interrupt or after the timeout.
+func stopped(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("search stopped before all the regions were searched: %w", err)
+	}
+	return nil
+}
+
+// profileRegionFunc initiates the results of a search for the given profile and region.
+type profileRegionFunc func(profile, region string) common.Results
+
+// maxParallel is the maximum number of searches running at the same time, see SetMaxParallel.
+var maxParallel = 0
+
+// SetMaxParallel sets the maximum number of searches running at the same time.
+//
+// Zero means no limit, all the searches run at the same time.
+func SetMaxParallel(n int) {
+	maxParallel = n
+}
+
+// regionTimeout is the timeout of each search in a profile and region, see SetRegionTimeout.
+var regionTimeout time.Duration
+
+// SetRegionTimeout sets the timeout of each search in a profile and region.
+//
+// Zero means no timeout.
+func SetRegionTimeout(d time.Duration) {
+	regionTimeout = d
+}
+
+// errorAdder is implemented by the results embedding common.BaseResults.
+type errorAdder interface {
+	AddError(err error)
+}
+
+// fanOut runs each search in all the given profiles and regions in parallel, see searchAll.
+//
+// The credentials of the profiles are checked first, see checkCredentials. The searches of the skipped
+// profiles are not run, their results are sent with the credentials error.
+// The results are sent to resultsChan, which must be buffered for all of them.
+// It returns all the results when all the searches are done, or an error if the credentials check fails.
+func fanOut(
+	ctx context.Context,
+	searches []profileRegionFunc,
+	profiles, regions []string,
+	resultsChan chan<- common.Results,
+) ([]common.Results, error) {
+	failed := map[string]error{}
+	if len(searches) > 0 && len(profiles) > 0 && len(regions) > 0 {
+		var err error
+		if failed, err = checkCredentials(ctx, profiles); err != nil {
+			return nil, err
+		}
+	}
+
+	all := make([]common.Results, 0, len(searches)*len(profiles)*len(regions))
+	pending := make([]common.Results, 0, cap(all))
+	for _, newResults := range searches {
+		for _, profile := range profiles {
+			for _, region := range regions {
+				searchResults := newResults(profile, region)
+				all = append(all, searchResults)
+
+				err, skip := failed[profile]
+				if !skip {
+					pending = append(pending, searchResults)
+					continue
 				}
-				runOnce = false
+				if e, ok := searchResults.(errorAdder); ok {
+					e.AddError(fmt.Errorf("search skipped, invalid credentials: %w", err))
+				}
+				resultsChan <- searchResults
 			}
+		}
+	}
 
-			switch cmd {
-			case "ec2":
-				searchResults = searchEC2.New(profile, region, filters, sortField)
-			case "eni":
-				searchResults = searchENI.New(profile, region, filters, sortField, noInstanceName)
-			case "ebs":
-				searchResults = searchEBS.New(profile, region, filters, sortField, noInstanceName)
-			default:
-				return fmt.Errorf("command %s not found", cmd)
-			}
+	searchAll(ctx, pending, resultsChan)
+
+	return all, nil
+}
+
+// searchAll runs the searches of the given results with a pool of maxParallel workers.
+//
+// Each result is sent to resultsChan once its search is done, which must be buffered for all of them.
+// Each search is stopped after the regionTimeout. Once the context is done, the searches not started yet
+// are not run, their results are sent with a cancelled error.
+// It returns when all the searches are done.
+func searchAll(ctx context.Context, pending []common.Results, resultsChan chan<- common.Results) {
+	workers := len(pending)
+	if maxParallel > 0 && maxParallel < workers {
+		workers = maxParallel
+	}
+
+	jobs := make(chan common.Results)
+	wg := sync.WaitGroup{}
 
-			wg.Add(1)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
 
-			go func() {
-				defer wg.Done()
+		go func() {
+			defer wg.Done()
 
-				searchResults.Search(ctx)
+			for searchResults := range jobs {
+				searchOne(ctx, searchResults)
 
 				resultsChan <- searchResults
-			}()
-		}
+			}
+		}()
 	}
 
-	wg.Wait()
-	close(resultsChan)
-	<-done
-	close(done)
+	for _, searchResults := range pending {
+		jobs <- searchResults
+	}
+	close(jobs)
 
-	return nil
+	wg.Wait()
 }
 
-// getSortFieldsCMDlist is a map of functions that return the sort fields for the given command.
+// searchOne runs the search of the given results with the regionTimeout.
 //
-// The key is the command name.
-// The value is the function that returns the sort fields.
-// We use a map to avoid a switch case and mock the functions in the tests.
-var getSortFieldsCMDList = map[string]func(string) (map[string]string, error){
-	"ec2": searchEC2.GetSortFields,
-	"eni": searchENI.GetSortFields,
-	"ebs": searchEBS.GetSortFields,
+// If the context is already done, the search is not run and a cancelled error is added to the results.
+func searchOne(ctx context.Context, searchResults common.Results) {
+	if err := ctx.Err(); err != nil {
+		if e, ok := searchResults.(errorAdder); ok {
+			e.AddError(fmt.Errorf("search cancelled: %w", err))
+		}
+		return
+	}
+
+	if regionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, regionTimeout)
+		defer cancel()
+	}
+
+	searchResults.Search(ctx)
 }
 
 // CheckSortField checks if the given sort field is valid for the given command.
 //
 // It returns an error if the sort field is not valid.
 func CheckSortField(cmd, f string) error {
-	execute, ok := getSortFieldsCMDList[cmd]
-	if !ok {
-		return fmt.Errorf("command %s not found", cmd)
+	resource, err := lookup(cmd)
+	if err != nil {
+		return err
 	}
 
-	if _, err := execute(f); err != nil {
+	if _, err := resource.GetSortFields(f); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// printResults prints the given results, in order, with common.PrintResults.
+func printResults(results []common.Results, output string, showEmpty, showTags bool) {
+	resultsChan := make(chan common.Results, len(results))
+	for _, r := range results {
+		resultsChan <- r
+	}
+	close(resultsChan)
+
+	done := make(chan bool)
+	go common.PrintResults(stdout, resultsChan, done, output, showEmpty, showTags)
+	<-done
+	close(done)
+}
+
+// CheckColumns checks if the given columns are valid for the given command.
+//
+// It returns an error if one of the columns is not valid, see common.CheckColumns.
+func CheckColumns(cmd string, columns []string) error {
+	resource, err := lookup(cmd)
+	if err != nil {
+		return err
+	}
+
+	return common.CheckColumns(resource.New("", "", nil, "", true), columns)
+}
@@ -22,8 +22,16 @@ limitations under the License.
 package search
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/dyegoe/awss/common"
 )
 
 // // TestExecute tests the Execute function.
@@ -66,16 +74,19 @@ import (
 
 // TestCheckSortField tests the checkSortField function.
 func TestCheckSortField(t *testing.T) {
-	// save the original function, defer the restore and mock the function
-	oldGetSortFieldsCMDList := getSortFieldsCMDList
-	defer func() { getSortFieldsCMDList = oldGetSortFieldsCMDList }()
-	getSortFieldsCMDList = map[string]func(string) (map[string]string, error){
-		"test": func(f string) (map[string]string, error) {
-			fields := map[string]string{"field1": "value1"}
-			if _, ok := fields[f]; !ok {
-				return nil, fmt.Errorf("field %s not found", f)
-			}
-			return fields, nil
+	// save the original registry, defer the restore and mock the registry
+	oldRegistry := registry
+	defer func() { registry = oldRegistry }()
+	registry = map[string]Resource{
+		"test": {
+			Name: "test",
+			GetSortFields: func(f string) (map[string]string, error) {
+				fields := map[string]string{"field1": "value1"}
+				if _, ok := fields[f]; !ok {
+					return nil, fmt.Errorf("field %s not found", f)
+				}
+				return fields, nil
+			},
 		},
 	}
 
@@ -112,3 +123,285 @@ func TestCheckSortField(t *testing.T) {
 		})
 	}
 }
+
+// columnsResults is a mockResults with rows, used to test the columns.
+type columnsResults struct {
+	mockResults
+	Data []struct {
+		ID string `json:"id,omitempty"`
+	} `json:"data"`
+}
+
+// TestCheckColumns tests the CheckColumns function.
+func TestCheckColumns(t *testing.T) {
+	// save the original registry, defer the restore and mock the registry
+	oldRegistry := registry
+	defer func() { registry = oldRegistry }()
+	registry = map[string]Resource{
+		"test": {
+			Name: "test",
+			New: func(_, _ string, _ map[string][]string, _ string, _ bool) common.Results {
+				return &columnsResults{}
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		cmd     string
+		columns []string
+		wantErr bool
+	}{
+		{name: "valid column", cmd: "test", columns: []string{"id"}},
+		{name: "invalid column", cmd: "test", columns: []string{"id", "name"}, wantErr: true},
+		{name: "command not found", cmd: "test2", columns: []string{"id"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckColumns(tt.cmd, tt.columns); (err != nil) != tt.wantErr {
+				t.Errorf("CheckColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestExecute_commandNotFound tests the Execute function with an unknown command.
+func TestExecute_commandNotFound(t *testing.T) {
+	oldRegistry := registry
+	defer func() { registry = oldRegistry }()
+	registry = map[string]Resource{}
+
+	if _, err := Execute(context.Background(), "invalid", nil, nil, nil, "", "", false, false, false, false); err == nil {
+		t.Error("Execute(invalid) expected error, got nil")
+	}
+}
+
+// delayedResults is a mockResults whose search takes the given delay.
+type delayedResults struct {
+	mockResults
+	delay time.Duration
+}
+
+func (d *delayedResults) Search(_ context.Context) { time.Sleep(d.delay) }
+
+// TestRunSearches tests the order of the results printed by the runSearches function.
+func TestRunSearches(t *testing.T) {
+	// save the original function and writer, defer the restore and mock them
+	oldPreAuth := preAuth
+	oldStdout := stdout
+	defer func() {
+		preAuth = oldPreAuth
+		stdout = oldStdout
+	}()
+	preAuth = func(_ context.Context, _, _ string) (common.Identity, error) {
+		return common.Identity{Account: "123456789012"}, nil
+	}
+
+	// the first regions are the slowest, so they are done last
+	delays := map[string]time.Duration{"r1": 60 * time.Millisecond, "r2": 30 * time.Millisecond, "r3": 0}
+	newResults := func(profile, region string) common.Results {
+		return &delayedResults{
+			mockResults: mockResults{BaseResults: common.BaseResults{Profile: profile, Region: region}},
+			delay:       delays[region],
+		}
+	}
+	regions := []string{"r1", "r2", "r3"}
+
+	tests := []struct {
+		name   string
+		stream bool
+		want   []string
+	}{
+		{name: "ordered", stream: false, want: []string{"r1", "r2", "r3"}},
+		{name: "stream", stream: true, want: []string{"r3", "r2", "r1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			stdout = &buffer
+
+			_, err := runSearches(
+				context.Background(),
+				[]TypeSearch{{}},
+				[]profileRegionFunc{newResults},
+				[]string{"p"}, regions,
+				common.JSON,
+				true, false, tt.stream,
+			)
+			if err != nil {
+				t.Fatalf("runSearches() unexpected error: %v", err)
+			}
+
+			got := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+				for _, region := range regions {
+					if strings.Contains(line, fmt.Sprintf("%q", region)) {
+						got = append(got, region)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runSearches()\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// countingResults is a mockResults counting the searches running at the same time.
+type countingResults struct {
+	mockResults
+	running, max *int32
+}
+
+func (c *countingResults) Search(_ context.Context) {
+	n := atomic.AddInt32(c.running, 1)
+	for {
+		m := atomic.LoadInt32(c.max)
+		if n <= m || atomic.CompareAndSwapInt32(c.max, m, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	atomic.AddInt32(c.running, -1)
+}
+
+// TestSearchAll tests the maximum number of searches running at the same time in the searchAll function.
+func TestSearchAll(t *testing.T) {
+	// save the original value, defer the restore and set the value
+	oldMaxParallel := maxParallel
+	defer func() { maxParallel = oldMaxParallel }()
+
+	tests := []struct {
+		name        string
+		maxParallel int
+		want        int32
+	}{
+		{name: "limited", maxParallel: 2, want: 2},
+		{name: "no limit", maxParallel: 0, want: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetMaxParallel(tt.maxParallel)
+
+			var running, maxRunning int32
+			pending := []common.Results{}
+			for i := 0; i < 6; i++ {
+				pending = append(pending, &countingResults{running: &running, max: &maxRunning})
+			}
+			resultsChan := make(chan common.Results, len(pending))
+
+			searchAll(context.Background(), pending, resultsChan)
+			close(resultsChan)
+
+			if got := len(resultsChan); got != len(pending) {
+				t.Errorf("searchAll() results\n%#v\nwant\n%#v", got, len(pending))
+			}
+			if maxRunning != tt.want {
+				t.Errorf("searchAll() running at the same time\n%#v\nwant\n%#v", maxRunning, tt.want)
+			}
+		})
+	}
+}
+
+// deadlineResults is a mockResults recording if its search had a deadline.
+type deadlineResults struct {
+	mockResults
+	searched, deadline bool
+}
+
+func (d *deadlineResults) Search(ctx context.Context) {
+	d.searched = true
+	_, d.deadline = ctx.Deadline()
+}
+
+// TestSearchOne tests the searchOne function.
+func TestSearchOne(t *testing.T) {
+	// save the original value, defer the restore and set the value
+	oldRegionTimeout := regionTimeout
+	defer func() { regionTimeout = oldRegionTimeout }()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		regionTimeout time.Duration
+		wantSearched  bool
+		wantDeadline  bool
+		wantErrors    []common.SearchError
+	}{
+		{name: "no timeout", ctx: context.Background(), wantSearched: true},
+		{
+			name: "region timeout", ctx: context.Background(), regionTimeout: time.Minute,
+			wantSearched: true, wantDeadline: true,
+		},
+		{
+			name: "cancelled", ctx: cancelled,
+			wantErrors: []common.SearchError{
+				{Category: common.CategoryCancelled, Message: "search cancelled: context canceled"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRegionTimeout(tt.regionTimeout)
+
+			r := &deadlineResults{}
+			searchOne(tt.ctx, r)
+
+			if r.searched != tt.wantSearched || r.deadline != tt.wantDeadline {
+				t.Errorf("searchOne() searched %v with deadline %v, want %v with deadline %v",
+					r.searched, r.deadline, tt.wantSearched, tt.wantDeadline)
+			}
+			if !reflect.DeepEqual(r.GetErrors(), tt.wantErrors) {
+				t.Errorf("searchOne() errors\n%#v\nwant\n%#v", r.GetErrors(), tt.wantErrors)
+			}
+		})
+	}
+}
+
+// TestRunSearches_cancelled tests the runSearches function with a done context.
+func TestRunSearches_cancelled(t *testing.T) {
+	// save the original function and writer, defer the restore and mock them
+	oldPreAuth := preAuth
+	oldStdout := stdout
+	defer func() {
+		preAuth = oldPreAuth
+		stdout = oldStdout
+	}()
+	preAuth = func(_ context.Context, _, _ string) (common.Identity, error) {
+		return common.Identity{Account: "123456789012"}, nil
+	}
+
+	buffer := bytes.Buffer{}
+	stdout = &buffer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	newResults := func(profile, region string) common.Results {
+		return &mockResults{BaseResults: common.BaseResults{Profile: profile, Region: region}}
+	}
+	summary, err := runSearches(
+		ctx,
+		[]TypeSearch{{}},
+		[]profileRegionFunc{newResults},
+		[]string{"p"}, []string{"r1", "r2"},
+		common.JSON,
+		true, false, false,
+	)
+	if err == nil {
+		t.Error("runSearches() expected error with a done context, got nil")
+	}
+	if !summary.AllFailed() {
+		t.Errorf("runSearches() summary\n%#v\nwant all the searches failed", summary)
+	}
+
+	cancelledError := `"errors":[{"category":"cancelled","message":"search cancelled: context canceled"}]`
+	want := `{"profile":"p","region":"r1",` + cancelledError + "}\n" +
+		`{"profile":"p","region":"r2",` + cancelledError + "}\n"
+	if got := buffer.String(); got != want {
+		t.Errorf("runSearches()\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sg
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/dyegoe/awss/common"
+	searchENI "github.com/dyegoe/awss/search/eni"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// openCidrs are the sources considered open to the internet.
+var openCidrs = []string{"0.0.0.0/0", "::/0"}
+
+// DefaultAuditPorts are the sensitive ports flagged by the audit when no port list is configured.
+//
+// SSH, RDP, MSSQL, Oracle, MySQL, PostgreSQL, Redshift, Redis, Cassandra, Elasticsearch, Memcached and MongoDB.
+var DefaultAuditPorts = []int{22, 3389, 1433, 1521, 3306, 5432, 5439, 6379, 9042, 9200, 11211, 27017}
+
+// AuditResults describes results of the security groups risky ingress audit.
+type AuditResults struct {
+	common.BaseResults
+
+	// Data contains the findings.
+	Data []auditRow `json:"data"`
+
+	// Filters is a map of strings used to search. Only "port" is used by the audit.
+	Filters map[string][]string `json:"-"`
+
+	// NoInstanceName skips the instance name lookup when true.
+	NoInstanceName bool `json:"-"`
+}
+
+// auditRow represents a rule that opens sensitive ports to the internet.
+type auditRow struct {
+	// GroupID is the ID of the security group.
+	GroupID string `json:"group_id,omitempty" header:"Group ID" sort:"group-id"`
+
+	// GroupName is the name of the security group.
+	GroupName string `json:"group_name,omitempty" header:"Group Name" sort:"group-name"`
+
+	// VpcID is the ID of the VPC the security group belongs to.
+	VpcID string `json:"vpc_id,omitempty" header:"VPC ID" sort:"vpc-id"`
+
+	// Rule is the protocol and port range of the rule.
+	Rule string `json:"rule,omitempty" header:"Rule" sort:"rule"`
+
+	// Source is the open CIDR allowed by the rule.
+	Source string `json:"source,omitempty" header:"Source" sort:"source"`
+
+	// SensitivePorts are the sensitive ports opened by the rule.
+	SensitivePorts []string `json:"sensitive_ports,omitempty" header:"Sensitive Ports"`
+
+	// NetworkInterfaces are the ENIs the security group is attached to.
+	NetworkInterfaces []string `json:"enis,omitempty" header:"ENIs"`
+
+	// Instances are the instances the security group is attached to, as `id (name)`.
+	Instances []string `json:"instances,omitempty" header:"Instances"`
+}
+
+// NewAudit initiates and returns a new instance of the security groups audit results.
+func NewAudit(
+	profile, region string, filters map[string][]string, sortField string, noInstanceName bool,
+) *AuditResults {
+	return &AuditResults{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:           []auditRow{},
+		Filters:        filters,
+		NoInstanceName: noInstanceName,
+	}
+}
+
+// Search performs the security groups audit.
+//
+// Every inbound rule that opens a sensitive port to 0.0.0.0/0 or ::/0 is a finding.
+// The findings are enriched with the ENIs and instances the security group is attached to.
+func (r *AuditResults) Search(ctx context.Context) {
+	ports, err := parsePorts(r.Filters[filterPort])
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+	if len(ports) == 0 {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: no sensitive ports to audit")))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(ec2.NewFromConfig(cfg), &ec2.DescribeSecurityGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.AddError(fmt.Errorf("error describing security groups: %w", err))
+			return
+		}
+		for _, sg := range page.SecurityGroups { //nolint:gocritic
+			r.Data = append(r.Data, auditSecurityGroup(&sg, ports)...)
+		}
+	}
+
+	r.enrichAttachments(ctx)
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// auditSecurityGroup returns one finding per inbound rule and open source that allows a sensitive port.
+func auditSecurityGroup(sg *types.SecurityGroup, ports []int32) []auditRow {
+	rows := []auditRow{}
+	for i := range sg.IpPermissions {
+		perm := &sg.IpPermissions[i]
+		sensitive := sensitivePorts(perm, ports)
+		if len(sensitive) == 0 {
+			continue
+		}
+		for _, source := range openSources(perm) {
+			rows = append(rows, auditRow{
+				GroupID:        common.StringValue(sg.GroupId),
+				GroupName:      common.StringValue(sg.GroupName),
+				VpcID:          common.StringValue(sg.VpcId),
+				Rule:           portRange(perm),
+				Source:         source,
+				SensitivePorts: sensitive,
+			})
+		}
+	}
+	return rows
+}
+
+// sensitivePorts returns the ports allowed by the rule.
+func sensitivePorts(perm *types.IpPermission, ports []int32) []string {
+	allowed := []string{}
+	for _, port := range ports {
+		if allowsPort(perm, port) {
+			allowed = append(allowed, fmt.Sprint(port))
+		}
+	}
+	return allowed
+}
+
+// openSources returns the open CIDRs allowed by the rule.
+func openSources(perm *types.IpPermission) []string {
+	sources := []string{}
+	for _, ip := range perm.IpRanges {
+		if cidr := common.StringValue(ip.CidrIp); common.StringInSlice(cidr, openCidrs) {
+			sources = append(sources, cidr)
+		}
+	}
+	for _, ip := range perm.Ipv6Ranges {
+		if cidr := common.StringValue(ip.CidrIpv6); common.StringInSlice(cidr, openCidrs) {
+			sources = append(sources, cidr)
+		}
+	}
+	return sources
+}
+
+// enrichAttachments fills the ENIs and instances of the findings.
+//
+// It runs an ENI search filtered by the security groups with findings, in chunks of common.MaxFilterValues groups.
+// The ENI search resolves the instance names with searchEC2.SearchInstanceNames.
+func (r *AuditResults) enrichAttachments(ctx context.Context) {
+	groupIDSet := map[string]struct{}{}
+	for i := range r.Data {
+		groupIDSet[r.Data[i].GroupID] = struct{}{}
+	}
+	if len(groupIDSet) == 0 {
+		return
+	}
+	groupIDs := make([]string, 0, len(groupIDSet))
+	for id := range groupIDSet {
+		groupIDs = append(groupIDs, id)
+	}
+	sort.Strings(groupIDs)
+
+	eniIDs := map[string][]string{}
+	instances := map[string][]string{}
+	for _, chunk := range common.ChunkValues(groupIDs, common.MaxFilterValues) {
+		enis := searchENI.New(r.Profile, r.Region, map[string][]string{"group-id": chunk}, "id", r.NoInstanceName)
+		enis.Search(ctx)
+		r.Errors = append(r.Errors, enis.GetErrors()...)
+
+		for i := range enis.Data {
+			info := enis.Data[i].InterfaceInfo
+			instance := instanceLabel(info.InstanceID, info.InstanceName)
+			for _, groupID := range enis.Data[i].SecurityGroups {
+				// the groups of other chunks are added by their own search
+				if !common.StringInSlice(groupID, chunk) {
+					continue
+				}
+				eniIDs[groupID] = append(eniIDs[groupID], info.NetworkInterfaceID)
+				if instance != "" && !common.StringInSlice(instance, instances[groupID]) {
+					instances[groupID] = append(instances[groupID], instance)
+				}
+			}
+		}
+	}
+
+	for i := range r.Data {
+		r.Data[i].NetworkInterfaces = eniIDs[r.Data[i].GroupID]
+		r.Data[i].Instances = instances[r.Data[i].GroupID]
+	}
+}
+
+// instanceLabel returns the instance as `id (name)`, or just the id when it has no name.
+func instanceLabel(id, name string) string {
+	if id == "" || name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", id, name)
+}
+
+// Len returns the length of the results.
+func (r *AuditResults) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *AuditResults) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(auditRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *AuditResults) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// sortResults sorts the results by the given field.
+func (r *AuditResults) sortResults(field string) error {
+	sortFields, err := GetAuditSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(r.Data, func(p, q int) bool {
+		sortField1 := reflect.ValueOf(r.Data[p]).FieldByName(sortFields[field]).String()
+		sortField2 := reflect.ValueOf(r.Data[q]).FieldByName(sortFields[field]).String()
+		return sortField1 < sortField2
+	})
+	return nil
+}
+
+// GetAuditSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on auditRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetAuditSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(auditRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
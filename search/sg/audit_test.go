@@ -0,0 +1,137 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// TestAuditSecurityGroup tests the auditSecurityGroup function.
+func TestAuditSecurityGroup(t *testing.T) {
+	sg := types.SecurityGroup{
+		GroupId:   common.String("sg-1234567890abcdef0"),
+		GroupName: common.String("bastion"),
+		VpcId:     common.String("vpc-1234567890abcdef0"),
+		IpPermissions: []types.IpPermission{
+			{
+				// SSH open to the world on IPv4 and IPv6: two findings.
+				IpProtocol: common.String("tcp"),
+				FromPort:   int32Ptr(22),
+				ToPort:     int32Ptr(22),
+				IpRanges:   []types.IpRange{{CidrIp: common.String("0.0.0.0/0")}},
+				Ipv6Ranges: []types.Ipv6Range{{CidrIpv6: common.String("::/0")}},
+			},
+			{
+				// HTTPS open to the world: not sensitive.
+				IpProtocol: common.String("tcp"),
+				FromPort:   int32Ptr(443),
+				ToPort:     int32Ptr(443),
+				IpRanges:   []types.IpRange{{CidrIp: common.String("0.0.0.0/0")}},
+			},
+			{
+				// All traffic from a private range: not open.
+				IpProtocol: common.String("-1"),
+				IpRanges:   []types.IpRange{{CidrIp: common.String("10.0.0.0/8")}},
+			},
+			{
+				// Port range covering MySQL and PostgreSQL open to the world.
+				IpProtocol: common.String("tcp"),
+				FromPort:   int32Ptr(3000),
+				ToPort:     int32Ptr(6000),
+				IpRanges:   []types.IpRange{{CidrIp: common.String("0.0.0.0/0")}},
+			},
+		},
+	}
+	base := auditRow{GroupID: "sg-1234567890abcdef0", GroupName: "bastion", VpcID: "vpc-1234567890abcdef0"}
+	want := []auditRow{base, base, base}
+	want[0].Rule, want[0].Source, want[0].SensitivePorts = "tcp/22", "0.0.0.0/0", []string{"22"}
+	want[1].Rule, want[1].Source, want[1].SensitivePorts = "tcp/22", "::/0", []string{"22"}
+	want[2].Rule, want[2].Source, want[2].SensitivePorts = "tcp/3000-6000", "0.0.0.0/0", []string{"3306", "5432"}
+
+	got := auditSecurityGroup(&sg, []int32{22, 3306, 5432})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("auditSecurityGroup()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestInstanceLabel tests the instanceLabel function.
+func TestInstanceLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		iname string
+		want  string
+	}{
+		{name: "id and name", id: "i-123", iname: "web", want: "i-123 (web)"},
+		{name: "id only", id: "i-123", want: "i-123"},
+		{name: "no instance", iname: "web", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceLabel(tt.id, tt.iname); got != tt.want {
+				t.Errorf("instanceLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAuditResults_GetHeaders tests the GetHeaders function.
+func TestAuditResults_GetHeaders(t *testing.T) {
+	r := NewAudit("", "", nil, "", false)
+	want := []interface{}{
+		"Group ID", "Group Name", "VPC ID", "Rule", "Source",
+		"Sensitive Ports", "ENIs", "Instances",
+	}
+	if got := r.GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AuditResults.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestAuditResults_sortResults tests the sortResults function.
+func TestAuditResults_sortResults(t *testing.T) {
+	r := NewAudit("", "", nil, "", false)
+	r.Data = []auditRow{
+		{GroupID: "sg-2", Source: "0.0.0.0/0"},
+		{GroupID: "sg-1", Source: "::/0"},
+	}
+	if err := r.sortResults("group-id"); err != nil {
+		t.Fatalf("sortResults(group-id) unexpected error: %v", err)
+	}
+	if r.Data[0].GroupID != "sg-1" {
+		t.Errorf("sortResults(group-id) first row = %s, want sg-1", r.Data[0].GroupID)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+}
+
+// TestGetAuditSortFields tests the GetAuditSortFields function.
+func TestGetAuditSortFields(t *testing.T) {
+	for _, f := range []string{"group-id", "group-name", "vpc-id", "rule", "source"} {
+		if _, err := GetAuditSortFields(f); err != nil {
+			t.Errorf("GetAuditSortFields(%q) unexpected error: %v", f, err)
+		}
+	}
+	if _, err := GetAuditSortFields("invalid"); err == nil {
+		t.Error("GetAuditSortFields(invalid) expected error, got nil")
+	}
+}
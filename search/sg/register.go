@@ -0,0 +1,120 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sg
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// AuditSearch is the search name of the security groups audit.
+const AuditSearch = "sg-audit"
+
+// cmdFilters represents the filters for the sg command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+// Ports is not an AWS filter, it is matched against the inbound rules port ranges after the search.
+type cmdFilters struct {
+	Ids          []string `filter:"group-id"`
+	Names        []string `filter:"group-name"`
+	Tags         []string `filter:"tag"`
+	TagsKey      []string `filter:"tag-key"`
+	VpcIDs       []string `filter:"vpc-id"`
+	Ports        []int    `filter:"port"`
+	Protocols    []string `filter:"ip-permission.protocol"`
+	Cidrs        []string `filter:"ip-permission.cidr"`
+	SourceGroups []string `filter:"ip-permission.group-id"`
+}
+
+// cmdF holds the values of the sg command filter flags.
+var cmdF = cmdFilters{}
+
+// The security groups search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "sg",
+	New:           search.WithoutInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"sg-"},
+	IDFilter:      "group-id",
+	Command: &search.Command{
+		Short: "Search for security groups.",
+		Long: `
+Search for security groups.
+You can search security groups using the following filters:
+  ids, names, tags, tags-key, vpc-ids, ports, protocols, cidrs and source-groups.
+The rule filters (ports, protocols, cidrs and source-groups) must all match the same inbound rule.
+You can use multiple values for each filter, separated by comma. Example: --ids sg-1230456078901,sg-1230456078902
+
+You can use multiple filters at same time, for example:
+	awss sg -p 22,3389 -c 0.0.0.0/0,::/0
+
+Use --all to search for all security groups without any filter. This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "security groups",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter security groups by ids. `sg-1230456078901,sg-1230456078902`",
+			},
+			{
+				Name: "names", Shorthand: "n", Value: &cmdF.Names,
+				Usage: "Filter security groups by group names. `web,database`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter security groups by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter security groups by tags key. `Key,Environment`",
+			},
+			{
+				Name: "vpc-ids", Shorthand: "v", Value: &cmdF.VpcIDs,
+				Usage: "Filter security groups by VPC IDs. `vpc-1230456078901,vpc-1230456078902`",
+			},
+			{
+				Name: "ports", Shorthand: "p", Value: &cmdF.Ports,
+				Usage: "Filter security groups by inbound rules allowing the ports. Port ranges are matched. `22,3389`",
+			},
+			{
+				Name: "protocols", Shorthand: "P", Value: &cmdF.Protocols,
+				Usage: "Filter security groups by inbound rules protocol. `tcp,udp,icmp,all`",
+			},
+			{
+				Name: "cidrs", Shorthand: "c", Value: &cmdF.Cidrs,
+				Usage: "Filter security groups by inbound rules source CIDR (IPv4 or IPv6). `0.0.0.0/0,::/0`",
+			},
+			{
+				Name: "source-groups", Shorthand: "g", Value: &cmdF.SourceGroups,
+				Usage: "Filter security groups by inbound rules referencing the groups. `sg-1230456078901`",
+			},
+		},
+		DefaultSort: "name",
+	},
+})
+
+// The security groups audit search is registered when the package is imported.
+// Its command is a subcommand of sg with its own flags, so it is defined in the cmd package.
+var _ = search.Register(search.Resource{
+	Name:          AuditSearch,
+	New:           search.WithInstanceName(NewAudit),
+	GetSortFields: GetAuditSortFields,
+})
@@ -0,0 +1,476 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sg contains the search for security groups.
+//
+// It implements the common.Results interface.
+package sg
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// protocolAll is the protocol value AWS uses for rules that allow all traffic.
+	protocolAll = "-1"
+
+	// filterPort is the filter key for the client-side port filter.
+	// AWS can only match exact rule ports, so port ranges are checked locally.
+	filterPort = "port"
+
+	// maxPort is the highest valid TCP/UDP port.
+	maxPort = 65535
+)
+
+// Results describes results of the security groups search.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the security groups found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search.
+	Filters map[string][]string `json:"-"`
+
+	// rules are the rule filters an inbound rule must match. They are matched after the API call.
+	rules ruleFilters
+}
+
+// ruleFilters are the filters matched against each inbound rule of the security groups.
+//
+// A security group matches if one of its inbound rules matches all the filters.
+// An empty filter matches every rule.
+type ruleFilters struct {
+	// ports are the ports the rule must allow.
+	ports []int32
+
+	// protocols are the protocols of the rule, as used by AWS, e.g. tcp or -1.
+	protocols []string
+
+	// cidrs are the IPv4 or IPv6 source CIDRs of the rule.
+	cidrs []string
+
+	// sourceGroups are the IDs of the security groups referenced by the rule.
+	sourceGroups []string
+}
+
+// dataRow represents a row of the security groups search results.
+type dataRow struct {
+	// GroupID is the ID of the security group.
+	GroupID string `json:"id,omitempty" header:"ID" sort:"id"`
+
+	// GroupName is the name of the security group.
+	GroupName string `json:"name,omitempty" header:"Name" sort:"name"`
+
+	// VpcID is the ID of the VPC the security group belongs to.
+	VpcID string `json:"vpc_id,omitempty" header:"VPC ID" sort:"vpc-id"`
+
+	// Description is the description of the security group.
+	Description string `json:"description,omitempty" header:"Description" sort:"description"`
+
+	// InboundRules are the summaries of the inbound rules.
+	InboundRules []string `json:"inbound_rules,omitempty" header:"Inbound Rules"`
+
+	// OutboundRules are the summaries of the outbound rules.
+	OutboundRules []string `json:"outbound_rules,omitempty" header:"Outbound Rules"`
+
+	// Tags are the tags assigned to the security group.
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// New initiates and returns a new instance of security groups results.
+func New(profile, region string, filters map[string][]string, sortField string) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:    []dataRow{},
+		Filters: filters,
+	}
+}
+
+// Search performs the security groups search.
+//
+// Results are stored in the Data field.
+func (r *Results) Search(ctx context.Context) {
+	input, err := r.getFilters()
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(ec2.NewFromConfig(cfg), input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.AddError(fmt.Errorf("error describing security groups: %w", err))
+			return
+		}
+		r.appendGroupRows(page.SecurityGroups)
+	}
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// appendGroupRows appends one row per security group with an inbound rule matching the rule filters.
+func (r *Results) appendGroupRows(groups []types.SecurityGroup) {
+	for _, sg := range groups { //nolint:gocritic
+		if !r.rules.matchAny(sg.IpPermissions) {
+			continue
+		}
+		r.Data = append(r.Data, parseSecurityGroup(&sg))
+	}
+}
+
+// parseSecurityGroup converts a single SecurityGroup into a dataRow.
+func parseSecurityGroup(sg *types.SecurityGroup) dataRow {
+	return dataRow{
+		GroupID:       common.StringValue(sg.GroupId),
+		GroupName:     common.StringValue(sg.GroupName),
+		VpcID:         common.StringValue(sg.VpcId),
+		Description:   common.StringValue(sg.Description),
+		InboundRules:  ruleSummaries(sg.IpPermissions, "from"),
+		OutboundRules: ruleSummaries(sg.IpPermissionsEgress, "to"),
+		Tags:          common.TagsToMap(sg.Tags),
+	}
+}
+
+// ruleSummaries returns one human readable line per peer of each rule.
+//
+// The preposition is used between the port and the peer, e.g. "from" for
+// inbound rules and "to" for outbound rules: `tcp/22 from 10.0.0.0/8`.
+func ruleSummaries(perms []types.IpPermission, preposition string) []string {
+	summaries := []string{}
+	for i := range perms {
+		ports := portRange(&perms[i])
+		for _, peer := range rulePeers(&perms[i]) {
+			summaries = append(summaries, fmt.Sprintf("%s %s %s", ports, preposition, peer))
+		}
+	}
+	return summaries
+}
+
+// portRange returns the protocol and port range of a rule, e.g. `tcp/22`, `udp/1024-2048` or `all`.
+func portRange(perm *types.IpPermission) string {
+	protocol := common.StringValue(perm.IpProtocol)
+	if protocol == protocolAll {
+		return "all"
+	}
+	if perm.FromPort == nil || perm.ToPort == nil || *perm.FromPort == -1 {
+		return protocol
+	}
+	if *perm.FromPort == *perm.ToPort {
+		return fmt.Sprintf("%s/%d", protocol, *perm.FromPort)
+	}
+	return fmt.Sprintf("%s/%d-%d", protocol, *perm.FromPort, *perm.ToPort)
+}
+
+// rulePeers returns the CIDRs, prefix lists and security groups referenced by a rule.
+func rulePeers(perm *types.IpPermission) []string {
+	peers := []string{}
+	for _, ip := range perm.IpRanges {
+		peers = append(peers, common.StringValue(ip.CidrIp))
+	}
+	for _, ip := range perm.Ipv6Ranges {
+		peers = append(peers, common.StringValue(ip.CidrIpv6))
+	}
+	for _, pl := range perm.PrefixListIds {
+		peers = append(peers, common.StringValue(pl.PrefixListId))
+	}
+	for _, pair := range perm.UserIdGroupPairs {
+		peers = append(peers, common.StringValue(pair.GroupId))
+	}
+	return peers
+}
+
+// allowsPort returns true if the rule allows traffic to the given port.
+//
+// Rules for all protocols allow every port. ICMP and other protocols without ports never match.
+func allowsPort(perm *types.IpPermission, port int32) bool {
+	protocol := common.StringValue(perm.IpProtocol)
+	if protocol == protocolAll {
+		return true
+	}
+	if protocol != "tcp" && protocol != "udp" && protocol != "6" && protocol != "17" {
+		return false
+	}
+	if perm.FromPort == nil || perm.ToPort == nil {
+		return true
+	}
+	return *perm.FromPort <= port && port <= *perm.ToPort
+}
+
+// matchAny returns true if any of the rules matches all the rule filters.
+//
+// Every security group matches if there is no rule filter.
+func (f *ruleFilters) matchAny(perms []types.IpPermission) bool {
+	if f.empty() {
+		return true
+	}
+	for i := range perms {
+		if f.match(&perms[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// empty returns true if there is no rule filter.
+func (f *ruleFilters) empty() bool {
+	return len(f.ports) == 0 && len(f.protocols) == 0 && len(f.cidrs) == 0 && len(f.sourceGroups) == 0
+}
+
+// match returns true if the rule matches all the rule filters.
+//
+// The port, the protocol and the peer are matched on the same rule,
+// so a rule allowing the port from another CIDR does not match.
+func (f *ruleFilters) match(perm *types.IpPermission) bool {
+	if len(f.ports) > 0 && !allowsAnyPort(perm, f.ports) {
+		return false
+	}
+	if len(f.protocols) > 0 && !matchAnyValue(f.protocols, common.StringValue(perm.IpProtocol)) {
+		return false
+	}
+	if len(f.cidrs) > 0 {
+		cidrs := []string{}
+		for _, ip := range perm.IpRanges {
+			cidrs = append(cidrs, common.StringValue(ip.CidrIp))
+		}
+		for _, ip := range perm.Ipv6Ranges {
+			cidrs = append(cidrs, common.StringValue(ip.CidrIpv6))
+		}
+		if !matchAnyValue(f.cidrs, cidrs...) {
+			return false
+		}
+	}
+	if len(f.sourceGroups) > 0 {
+		groups := []string{}
+		for _, pair := range perm.UserIdGroupPairs {
+			groups = append(groups, common.StringValue(pair.GroupId))
+		}
+		if !matchAnyValue(f.sourceGroups, groups...) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsAnyPort returns true if the rule allows any of the ports.
+func allowsAnyPort(perm *types.IpPermission, ports []int32) bool {
+	for _, port := range ports {
+		if allowsPort(perm, port) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAnyValue returns true if any of the values matches any of the patterns.
+//
+// The patterns can use the wildcard '*', like the AWS filters.
+func matchAnyValue(patterns []string, values ...string) bool {
+	for _, pattern := range patterns {
+		re := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$")
+		for _, v := range values {
+			if re.MatchString(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getFilters returns the filters used to search.
+//
+// The filters are defined in the results.Filters field.
+// The rule filters are kept in results.rules and matched against each inbound rule after the search, see ruleFilters.
+// "port" is not an AWS filter. "ip-permission.cidr" is only sent to AWS if all the CIDRs are of the same family,
+// as "ip-permission.ipv6-cidr" for IPv6, because AWS requires a rule of each family if both filters are sent.
+// Except for "group-id", "tag", "ip-permission.protocol" and the above, all other filters are passed as-is.
+func (r *Results) getFilters() (*ec2.DescribeSecurityGroupsInput, error) {
+	input := ec2.DescribeSecurityGroupsInput{}
+
+	for key, values := range r.Filters {
+		switch key {
+		case "group-id":
+			input.GroupIds = values
+		case "tag":
+			tagFilters, err := common.FilterTags(values)
+			if err != nil {
+				return nil, fmt.Errorf("building tag filters: %w", err)
+			}
+			input.Filters = append(input.Filters, tagFilters...)
+		case "ip-permission.protocol":
+			r.rules.protocols = normalizeProtocols(values)
+			input.Filters = append(input.Filters, common.FilterDefault(key, r.rules.protocols)...)
+		case "ip-permission.cidr":
+			r.rules.cidrs = values
+			v4, v6 := splitCidrs(values)
+			if len(v6) == 0 {
+				input.Filters = append(input.Filters, common.FilterDefault(key, v4)...)
+			} else if len(v4) == 0 {
+				input.Filters = append(input.Filters, common.FilterDefault("ip-permission.ipv6-cidr", v6)...)
+			}
+		case "ip-permission.group-id":
+			r.rules.sourceGroups = values
+			input.Filters = append(input.Filters, common.FilterDefault(key, values)...)
+		case filterPort:
+			ports, err := parsePorts(values)
+			if err != nil {
+				return nil, fmt.Errorf("building port filters: %w", err)
+			}
+			r.rules.ports = ports
+		default:
+			input.Filters = append(input.Filters, common.FilterDefault(key, values)...)
+		}
+	}
+	return &input, nil
+}
+
+// parsePorts converts a slice of strings into a slice of ports.
+//
+// It returns an error if any value is not a number between 0 and 65535.
+func parsePorts(values []string) ([]int32, error) {
+	ports := make([]int32, 0, len(values))
+	for _, v := range values {
+		port, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || port < 0 || port > maxPort {
+			return nil, fmt.Errorf("invalid port: %s", v)
+		}
+		ports = append(ports, int32(port))
+	}
+	return ports, nil
+}
+
+// normalizeProtocols replaces the `all` protocol with the value used by AWS.
+func normalizeProtocols(values []string) []string {
+	protocols := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.EqualFold(v, "all") {
+			v = protocolAll
+		}
+		protocols = append(protocols, strings.ToLower(v))
+	}
+	return protocols
+}
+
+// splitCidrs splits a list of CIDRs into IPv4 and IPv6 CIDRs.
+func splitCidrs(values []string) (v4, v6 []string) {
+	for _, v := range values {
+		if strings.Contains(v, ":") {
+			v6 = append(v6, v)
+			continue
+		}
+		v4 = append(v4, v)
+	}
+	return v4, v6
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(r.Data, func(p, q int) bool {
+		sortField1 := reflect.ValueOf(r.Data[p]).FieldByName(sortFields[field]).String()
+		sortField2 := reflect.ValueOf(r.Data[q]).FieldByName(sortFields[field]).String()
+		return sortField1 < sortField2
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
@@ -0,0 +1,418 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sg contains the search for security groups.
+//
+// It implements the common.Results interface.
+package sg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{"group-id": {"sg-123"}}, "name")
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "name",
+		},
+		Data:    []dataRow{},
+		Filters: map[string][]string{"group-id": {"sg-123"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+var mockDataRow1 = dataRow{
+	GroupID:       "sg-1234567890abcdef0",
+	GroupName:     "web",
+	VpcID:         "vpc-1234567890abcdef0",
+	Description:   "web servers",
+	InboundRules:  []string{"tcp/443 from 0.0.0.0/0"},
+	OutboundRules: []string{"all to 0.0.0.0/0"},
+	Tags:          map[string]string{"Environment": "prod"},
+}
+
+var mockDataRow2 = dataRow{
+	GroupID:       "sg-1234567890abcdef1",
+	GroupName:     "database",
+	VpcID:         "vpc-1234567890abcdef0",
+	Description:   "database servers",
+	InboundRules:  []string{"tcp/5432 from sg-1234567890abcdef0"},
+	OutboundRules: []string{"all to 0.0.0.0/0"},
+	Tags:          map[string]string{"Environment": "prod"},
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	r := New("", "", nil, "")
+	want := []interface{}{"ID", "Name", "VPC ID", "Description", "Inbound Rules", "Outbound Rules", "Tags"}
+	if got := r.GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetRows tests the GetRows function.
+func TestResults_GetRows(t *testing.T) {
+	r := New("", "", nil, "")
+	r.Data = []dataRow{mockDataRow1, mockDataRow2}
+	want := []interface{}{mockDataRow1, mockDataRow2}
+	if got := r.GetRows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetRows()\n%#v\nwant\n%#v", got, want)
+	}
+	if got := r.Len(); got != 2 {
+		t.Errorf("Results.Len() = %d, want 2", got)
+	}
+}
+
+// TestResults_getFilters tests the getFilters function.
+func TestResults_getFilters(t *testing.T) {
+	r := New("default", "us-east-1", map[string][]string{
+		"group-id":               {"sg-1234567890abcdef0"},
+		"tag":                    {"key=value:value3"},
+		"vpc-id":                 {"vpc-1234567890abcdef0"},
+		"ip-permission.protocol": {"TCP", "all"},
+		"ip-permission.cidr":     {"0.0.0.0/0", "::/0"},
+		"port":                   {"22", "3389"},
+	}, "name")
+	want := &ec2.DescribeSecurityGroupsInput{
+		GroupIds: []string{"sg-1234567890abcdef0"},
+		Filters: []types.Filter{
+			{Name: common.String("tag:key"), Values: []string{"value", "value3"}},
+			{Name: common.String("vpc-id"), Values: []string{"vpc-1234567890abcdef0"}},
+			{Name: common.String("ip-permission.protocol"), Values: []string{"tcp", "-1"}},
+		},
+	}
+	wantRules := ruleFilters{
+		ports:     []int32{22, 3389},
+		protocols: []string{"tcp", "-1"},
+		cidrs:     []string{"0.0.0.0/0", "::/0"},
+	}
+
+	got, err := r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.GroupIds, want.GroupIds) {
+		t.Errorf("GroupIds = %v, want %v", got.GroupIds, want.GroupIds)
+	}
+	if len(got.Filters) != len(want.Filters) {
+		t.Fatalf("Filters count = %d, want %d", len(got.Filters), len(want.Filters))
+	}
+	gotByName := make(map[string][]string, len(got.Filters))
+	for _, f := range got.Filters {
+		gotByName[*f.Name] = f.Values
+	}
+	for _, wf := range want.Filters {
+		if gv := gotByName[*wf.Name]; !reflect.DeepEqual(gv, wf.Values) {
+			t.Errorf("filter %q values = %v, want %v", *wf.Name, gv, wf.Values)
+		}
+	}
+	if !reflect.DeepEqual(r.rules, wantRules) {
+		t.Errorf("rules\n%#v\nwant\n%#v", r.rules, wantRules)
+	}
+}
+
+// TestResults_getFilters_cidrs tests the AWS filters of the CIDRs of a single family.
+func TestResults_getFilters_cidrs(t *testing.T) {
+	tests := []struct {
+		name  string
+		cidrs []string
+		want  []types.Filter
+	}{
+		{
+			name:  "IPv4 CIDRs",
+			cidrs: []string{"0.0.0.0/0", "10.0.0.0/8"},
+			want:  []types.Filter{{Name: common.String("ip-permission.cidr"), Values: []string{"0.0.0.0/0", "10.0.0.0/8"}}},
+		},
+		{
+			name:  "IPv6 CIDRs",
+			cidrs: []string{"::/0"},
+			want:  []types.Filter{{Name: common.String("ip-permission.ipv6-cidr"), Values: []string{"::/0"}}},
+		},
+		{
+			name:  "IPv4 and IPv6 CIDRs are only matched after the search",
+			cidrs: []string{"0.0.0.0/0", "::/0"},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New("", "", map[string][]string{"ip-permission.cidr": tt.cidrs}, "")
+			got, err := r.getFilters()
+			if err != nil {
+				t.Fatalf("Results.getFilters() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Filters, tt.want) {
+				t.Errorf("Results.getFilters()\n%#v\nwant\n%#v", got.Filters, tt.want)
+			}
+		})
+	}
+}
+
+// TestResults_getFilters_invalid tests getFilters with malformed filters.
+func TestResults_getFilters_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters map[string][]string
+	}{
+		{name: "malformed tag", filters: map[string][]string{"tag": {"invalid"}}},
+		{name: "port out of range", filters: map[string][]string{"port": {"70000"}}},
+		{name: "port not a number", filters: map[string][]string{"port": {"ssh"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New("", "", tt.filters, "")
+			if _, err := r.getFilters(); err == nil {
+				t.Error("Results.getFilters() expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestParseSecurityGroup tests the parseSecurityGroup function.
+func TestParseSecurityGroup(t *testing.T) {
+	sg := types.SecurityGroup{
+		GroupId:     common.String("sg-1234567890abcdef0"),
+		GroupName:   common.String("web"),
+		VpcId:       common.String("vpc-1234567890abcdef0"),
+		Description: common.String("web servers"),
+		IpPermissions: []types.IpPermission{
+			{
+				IpProtocol: common.String("tcp"),
+				FromPort:   int32Ptr(443),
+				ToPort:     int32Ptr(443),
+				IpRanges:   []types.IpRange{{CidrIp: common.String("0.0.0.0/0")}},
+			},
+		},
+		IpPermissionsEgress: []types.IpPermission{
+			{
+				IpProtocol: common.String("-1"),
+				IpRanges:   []types.IpRange{{CidrIp: common.String("0.0.0.0/0")}},
+			},
+		},
+		Tags: []types.Tag{{Key: common.String("Environment"), Value: common.String("prod")}},
+	}
+	if got := parseSecurityGroup(&sg); !reflect.DeepEqual(got, mockDataRow1) {
+		t.Errorf("parseSecurityGroup()\n%#v\nwant\n%#v", got, mockDataRow1)
+	}
+}
+
+// TestRuleSummaries tests the ruleSummaries function.
+func TestRuleSummaries(t *testing.T) {
+	perms := []types.IpPermission{
+		{
+			IpProtocol: common.String("tcp"),
+			FromPort:   int32Ptr(80),
+			ToPort:     int32Ptr(443),
+			IpRanges:   []types.IpRange{{CidrIp: common.String("10.0.0.0/8")}},
+			Ipv6Ranges: []types.Ipv6Range{{CidrIpv6: common.String("::/0")}},
+		},
+		{
+			IpProtocol:       common.String("udp"),
+			FromPort:         int32Ptr(53),
+			ToPort:           int32Ptr(53),
+			PrefixListIds:    []types.PrefixListId{{PrefixListId: common.String("pl-123")}},
+			UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: common.String("sg-123")}},
+		},
+		{
+			IpProtocol: common.String("icmp"),
+			FromPort:   int32Ptr(-1),
+			ToPort:     int32Ptr(-1),
+			IpRanges:   []types.IpRange{{CidrIp: common.String("10.0.0.0/8")}},
+		},
+	}
+	want := []string{
+		"tcp/80-443 from 10.0.0.0/8",
+		"tcp/80-443 from ::/0",
+		"udp/53 from pl-123",
+		"udp/53 from sg-123",
+		"icmp from 10.0.0.0/8",
+	}
+	if got := ruleSummaries(perms, "from"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ruleSummaries()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestAllowsPort tests the allowsPort function.
+func TestAllowsPort(t *testing.T) {
+	tests := []struct {
+		name string
+		perm types.IpPermission
+		port int32
+		want bool
+	}{
+		{
+			name: "all protocols",
+			perm: types.IpPermission{IpProtocol: common.String("-1")},
+			port: 22,
+			want: true,
+		},
+		{
+			name: "port in range",
+			perm: types.IpPermission{IpProtocol: common.String("tcp"), FromPort: int32Ptr(0), ToPort: int32Ptr(1024)},
+			port: 22,
+			want: true,
+		},
+		{
+			name: "port out of range",
+			perm: types.IpPermission{IpProtocol: common.String("tcp"), FromPort: int32Ptr(80), ToPort: int32Ptr(443)},
+			port: 22,
+			want: false,
+		},
+		{
+			name: "icmp never matches",
+			perm: types.IpPermission{IpProtocol: common.String("icmp"), FromPort: int32Ptr(-1), ToPort: int32Ptr(-1)},
+			port: 22,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allowsPort(&tt.perm, tt.port); got != tt.want {
+				t.Errorf("allowsPort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRuleFilters_matchAny tests the ruleFilters.matchAny function.
+func TestRuleFilters_matchAny(t *testing.T) {
+	// tcp/22 from 10.0.0.0/8 and tcp/443 from 0.0.0.0/0
+	perms := []types.IpPermission{
+		{
+			IpProtocol: common.String("tcp"), FromPort: int32Ptr(22), ToPort: int32Ptr(22),
+			IpRanges: []types.IpRange{{CidrIp: common.String("10.0.0.0/8")}},
+		},
+		{
+			IpProtocol: common.String("tcp"), FromPort: int32Ptr(443), ToPort: int32Ptr(443),
+			IpRanges: []types.IpRange{{CidrIp: common.String("0.0.0.0/0")}},
+		},
+	}
+	ipv6Perms := []types.IpPermission{
+		{
+			IpProtocol: common.String("tcp"), FromPort: int32Ptr(22), ToPort: int32Ptr(22),
+			Ipv6Ranges:       []types.Ipv6Range{{CidrIpv6: common.String("::/0")}},
+			UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: common.String("sg-123")}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		filters ruleFilters
+		perms   []types.IpPermission
+		want    bool
+	}{
+		{name: "no filters", perms: nil, want: true},
+		{
+			name:    "port and CIDR of different rules",
+			filters: ruleFilters{ports: []int32{22}, cidrs: []string{"0.0.0.0/0"}},
+			perms:   perms,
+			want:    false,
+		},
+		{
+			name:    "port and CIDR of the same rule",
+			filters: ruleFilters{ports: []int32{443}, cidrs: []string{"0.0.0.0/0"}},
+			perms:   perms,
+			want:    true,
+		},
+		{
+			name:    "IPv4 or IPv6 CIDRs",
+			filters: ruleFilters{ports: []int32{22, 3389}, cidrs: []string{"0.0.0.0/0", "::/0"}},
+			perms:   ipv6Perms,
+			want:    true,
+		},
+		{
+			name:    "wildcard CIDR",
+			filters: ruleFilters{cidrs: []string{"10.*"}},
+			perms:   perms,
+			want:    true,
+		},
+		{
+			name:    "protocol of another rule",
+			filters: ruleFilters{ports: []int32{22}, protocols: []string{"udp"}},
+			perms:   perms,
+			want:    false,
+		},
+		{
+			name:    "source group",
+			filters: ruleFilters{ports: []int32{22}, sourceGroups: []string{"sg-123"}},
+			perms:   ipv6Perms,
+			want:    true,
+		},
+		{
+			name:    "source group of another rule",
+			filters: ruleFilters{ports: []int32{443}, sourceGroups: []string{"sg-123"}},
+			perms:   append(perms, ipv6Perms...),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filters.matchAny(tt.perms); got != tt.want {
+				t.Errorf("ruleFilters.matchAny() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	r := New("", "", nil, "")
+	r.Data = []dataRow{mockDataRow1, mockDataRow2}
+	if err := r.sortResults("name"); err != nil {
+		t.Fatalf("sortResults(name) unexpected error: %v", err)
+	}
+	if r.Data[0].GroupName != "database" {
+		t.Errorf("sortResults(name) first row = %s, want database", r.Data[0].GroupName)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	want := map[string]string{
+		"id":          "GroupID",
+		"name":        "GroupName",
+		"vpc-id":      "VpcID",
+		"description": "Description",
+	}
+	got, err := GetSortFields("id")
+	if err != nil {
+		t.Fatalf("GetSortFields() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortFields()\n%#v\nwant\n%#v", got, want)
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the snapshot command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids           []string `filter:"snapshot-id"`
+	Tags          []string `filter:"tag"`
+	TagsKey       []string `filter:"tag-key"`
+	VolumeIDs     []string `filter:"volume-id"`
+	Statuses      []string `filter:"status"`
+	Encrypted     []string `filter:"encrypted"`
+	OlderThan     []int    `filter:"older-than"`
+	VolumeDeleted []string `filter:"volume-deleted"`
+	Owners        []string `filter:"owner"`
+}
+
+// cmdF holds the values of the snapshot command filter flags.
+var cmdF = cmdFilters{}
+
+// The EBS snapshots search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "snapshot",
+	New:           search.WithoutInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"snap-"},
+	IDFilter:      "snapshot-id",
+	Command: &search.Command{
+		Short: "Search for EBS snapshots.",
+		Long: `
+Search for EBS snapshots.
+Only the snapshots owned by the account are searched, unless --owners or --ids is given.
+You can search EBS snapshots using the following filters:
+  ids, tags, tags-key, volume-ids, statuses, encrypted, older-than, volume-deleted and owners.
+You can use multiple values for each filter, separated by comma.
+Example: --ids snap-1230456078901,snap-1230456078902
+
+You can use multiple filters at same time. For example, to find the snapshots older than 90 days
+whose source volume was deleted:
+	awss snapshot --older-than 90 --volume-deleted true
+
+Use --all to search for all EBS snapshots without any filter.
+This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "EBS snapshots",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter EBS snapshots by IDs. `snap-1230456078901,snap-1230456078902`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter EBS snapshots by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter EBS snapshots by tags key. `Key,Environment`",
+			},
+			{
+				Name: "volume-ids", Shorthand: "v", Value: &cmdF.VolumeIDs,
+				Usage: "Filter EBS snapshots by source volume IDs. `vol-1230456078901,vol-1230456078902`",
+			},
+			{
+				Name: "statuses", Shorthand: "s", Value: &cmdF.Statuses,
+				Usage: "Filter EBS snapshots by status. `pending,completed,error`",
+			},
+			{
+				Name: "encrypted", Shorthand: "e", Value: &cmdF.Encrypted,
+				Usage: "Filter EBS snapshots by encryption. `true,false`",
+			},
+			{
+				Name: "older-than", Shorthand: "o", Value: &cmdF.OlderThan,
+				Usage: "Filter EBS snapshots started more than the given number of days ago. `90`",
+			},
+			{
+				Name: "volume-deleted", Shorthand: "d", Value: &cmdF.VolumeDeleted,
+				Usage: "Filter EBS snapshots by whether their source volume was deleted. `true,false`",
+			},
+			{
+				Name: "owners", Shorthand: "O", Value: &cmdF.Owners,
+				Usage: "Filter EBS snapshots by owner account IDs or aliases. `self,amazon,123456789012`",
+			},
+		},
+		DefaultSort: "start-time",
+	},
+})
@@ -0,0 +1,395 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot contains the search for EBS snapshots.
+//
+// It implements the common.Results interface.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// FilterOlderThan is the filter key used to keep only the snapshots older than a number of days.
+	//
+	// The EC2 API has no filter for it, so it is applied to the results.
+	FilterOlderThan = "older-than"
+
+	// FilterVolumeDeleted is the filter key used to keep only the snapshots whose source volume
+	// was deleted, or still exists. Values are `true` and/or `false`.
+	//
+	// The EC2 API has no filter for it, so it is applied to the results.
+	FilterVolumeDeleted = "volume-deleted"
+
+	// ownerSelf is the default owner of the searched snapshots.
+	ownerSelf = "self"
+
+	// hoursPerDay is used to convert the snapshot age to days.
+	hoursPerDay = 24
+)
+
+// now returns the current time.
+// We use a variable to mock it in the tests.
+var now = time.Now
+
+// Results describes results of the EBS snapshots search.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the snapshots found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search.
+	Filters map[string][]string `json:"-"`
+
+	// olderThan is the minimum age in days parsed from the "older-than" filter. It is 0 when the filter is not used.
+	olderThan int
+
+	// volumeDeleted holds the accepted states parsed from the "volume-deleted" filter.
+	// It is empty when the filter is not used.
+	volumeDeleted []bool
+}
+
+// dataRow represents a row of the EBS snapshots search results.
+type dataRow struct {
+	// SnapshotID is the ID of the snapshot.
+	SnapshotID string `json:"id,omitempty" header:"ID" sort:"id"`
+
+	// VolumeID is the ID of the volume the snapshot was created from.
+	VolumeID string `json:"volume_id,omitempty" header:"Volume ID" sort:"volume-id"`
+
+	// VolumeDeleted indicates whether the source volume no longer exists.
+	VolumeDeleted string `json:"volume_deleted,omitempty" header:"Volume Deleted" sort:"volume-deleted"`
+
+	// Size is the size of the source volume in GiB.
+	Size int32 `json:"size,omitempty" header:"Size (GiB)" sort:"size"`
+
+	// StartTime is the time the snapshot was initiated, in RFC 3339 format.
+	StartTime string `json:"start_time,omitempty" header:"Start Time" sort:"start-time"`
+
+	// Age is the number of days since the snapshot was initiated.
+	Age int `json:"age_days" header:"Age (days)" sort:"age"`
+
+	// State is the state of the snapshot.
+	State string `json:"state,omitempty" header:"State" sort:"state"`
+
+	// Progress is the progress of the snapshot, as a percentage.
+	Progress string `json:"progress,omitempty" header:"Progress" sort:"progress"`
+
+	// Encrypted indicates whether the snapshot is encrypted.
+	Encrypted string `json:"encrypted,omitempty" header:"Encrypted" sort:"encrypted"`
+
+	// KmsKeyID is the ARN of the KMS key used to encrypt the snapshot.
+	KmsKeyID string `json:"kms_key_id,omitempty" header:"KMS Key"`
+
+	// Description is the description of the snapshot.
+	Description string `json:"description,omitempty" header:"Description"`
+
+	// Tags are the tags assigned to the snapshot.
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// New initiates and returns a new instance of EBS snapshots results.
+func New(profile, region string, filters map[string][]string, sortField string) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:    []dataRow{},
+		Filters: filters,
+	}
+}
+
+// Search performs the EBS snapshots search.
+//
+// Results are stored in the Data field.
+func (r *Results) Search(ctx context.Context) {
+	input, err := r.getFilters()
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+	client := ec2.NewFromConfig(cfg)
+
+	paginator := ec2.NewDescribeSnapshotsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.AddError(fmt.Errorf("error describing snapshots: %w", err))
+			return
+		}
+		for _, snapshot := range page.Snapshots { //nolint:gocritic
+			row := parseSnapshot(&snapshot, now())
+			if row.Age < r.olderThan {
+				continue
+			}
+			r.Data = append(r.Data, row)
+		}
+	}
+
+	if err := r.markDeletedVolumes(ctx, client); err != nil {
+		r.AddError(err)
+		return
+	}
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// parseSnapshot converts a single Snapshot into a dataRow.
+//
+// The age is computed from the given current time.
+func parseSnapshot(snapshot *types.Snapshot, currentTime time.Time) dataRow {
+	row := dataRow{
+		SnapshotID:  common.StringValue(snapshot.SnapshotId),
+		VolumeID:    common.StringValue(snapshot.VolumeId),
+		State:       string(snapshot.State),
+		Progress:    common.StringValue(snapshot.Progress),
+		KmsKeyID:    common.StringValue(snapshot.KmsKeyId),
+		Description: common.StringValue(snapshot.Description),
+		Tags:        common.TagsToMap(snapshot.Tags),
+	}
+	if snapshot.VolumeSize != nil {
+		row.Size = *snapshot.VolumeSize
+	}
+	if snapshot.Encrypted != nil {
+		row.Encrypted = strconv.FormatBool(*snapshot.Encrypted)
+	}
+	if snapshot.StartTime != nil {
+		row.StartTime = snapshot.StartTime.UTC().Format(time.RFC3339)
+		row.Age = int(currentTime.Sub(*snapshot.StartTime).Hours() / hoursPerDay)
+	}
+	return row
+}
+
+// markDeletedVolumes sets the VolumeDeleted field of the rows, then applies the "volume-deleted" filter.
+func (r *Results) markDeletedVolumes(ctx context.Context, client *ec2.Client) error {
+	volumeIDSet := map[string]struct{}{}
+	for i := range r.Data {
+		if id := r.Data[i].VolumeID; id != "" {
+			volumeIDSet[id] = struct{}{}
+		}
+	}
+	volumeIDs := make([]string, 0, len(volumeIDSet))
+	for id := range volumeIDSet {
+		volumeIDs = append(volumeIDs, id)
+	}
+	sort.Strings(volumeIDs)
+
+	existing, err := existingVolumes(ctx, client, volumeIDs)
+	if err != nil {
+		return err
+	}
+	r.applyVolumeDeleted(existing)
+	return nil
+}
+
+// existingVolumes returns the set of the given volumes that still exist.
+//
+// The volumes are looked up with the volume-id filter, which, unlike VolumeIds,
+// does not fail when a volume does not exist.
+func existingVolumes(ctx context.Context, client *ec2.Client, volumeIDs []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	for _, chunk := range common.ChunkValues(volumeIDs, common.MaxFilterValues) {
+		paginator := ec2.NewDescribeVolumesPaginator(client, &ec2.DescribeVolumesInput{
+			Filters: []types.Filter{{Name: common.String("volume-id"), Values: chunk}},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error describing volumes: %w", err)
+			}
+			for i := range page.Volumes {
+				existing[common.StringValue(page.Volumes[i].VolumeId)] = true
+			}
+		}
+	}
+	return existing, nil
+}
+
+// applyVolumeDeleted sets the VolumeDeleted field of the rows from the set of existing volumes
+// and drops the rows not matching the "volume-deleted" filter.
+func (r *Results) applyVolumeDeleted(existing map[string]bool) {
+	data := make([]dataRow, 0, len(r.Data))
+	for i := range r.Data {
+		deleted := !existing[r.Data[i].VolumeID]
+		if !common.MatchBool(deleted, r.volumeDeleted) {
+			continue
+		}
+		r.Data[i].VolumeDeleted = strconv.FormatBool(deleted)
+		data = append(data, r.Data[i])
+	}
+	r.Data = data
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getFilters returns the filters used to search.
+//
+// The filters are defined in the results.Filters field.
+// The snapshots owned by the account are searched, unless owners or snapshot IDs are given.
+// The "older-than" and "volume-deleted" filters are not AWS filters. They are parsed and applied to the results.
+// Except for "snapshot-id", "owner", "tag", "older-than" and "volume-deleted", all other filters are passed as-is.
+func (r *Results) getFilters() (*ec2.DescribeSnapshotsInput, error) {
+	input := ec2.DescribeSnapshotsInput{}
+
+	for key, values := range r.Filters {
+		switch key {
+		case "snapshot-id":
+			input.SnapshotIds = values
+		case "owner":
+			input.OwnerIds = values
+		case "tag":
+			tagFilters, err := common.FilterTags(values)
+			if err != nil {
+				return nil, fmt.Errorf("building tag filters: %w", err)
+			}
+			input.Filters = append(input.Filters, tagFilters...)
+		case FilterOlderThan:
+			olderThan, err := parseOlderThan(values)
+			if err != nil {
+				return nil, err
+			}
+			r.olderThan = olderThan
+		case FilterVolumeDeleted:
+			volumeDeleted, err := common.ParseBools(key, values)
+			if err != nil {
+				return nil, err
+			}
+			r.volumeDeleted = volumeDeleted
+		default:
+			input.Filters = append(input.Filters, common.FilterDefault(key, values)...)
+		}
+	}
+	if len(input.OwnerIds) == 0 && len(input.SnapshotIds) == 0 {
+		input.OwnerIds = []string{ownerSelf}
+	}
+	return &input, nil
+}
+
+// parseOlderThan parses the values of the "older-than" filter.
+//
+// A snapshot is older than one of the values if it is older than the smallest one.
+func parseOlderThan(values []string) (int, error) {
+	olderThan := -1
+	for _, v := range values {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return 0, fmt.Errorf("invalid %s value: %s. It must be a number of days", FilterOlderThan, v)
+		}
+		if olderThan == -1 || days < olderThan {
+			olderThan = days
+		}
+	}
+	return max(olderThan, 0), nil
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	fieldName := sortFields[field]
+	sort.Slice(r.Data, func(p, q int) bool {
+		pField := reflect.ValueOf(r.Data[p]).FieldByName(fieldName)
+		qField := reflect.ValueOf(r.Data[q]).FieldByName(fieldName)
+		if pField.Kind() == reflect.Int32 || pField.Kind() == reflect.Int {
+			return pField.Int() < qField.Int()
+		}
+		return pField.String() < qField.String()
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
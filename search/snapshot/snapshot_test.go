@@ -0,0 +1,232 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot contains the search for EBS snapshots.
+//
+// It implements the common.Results interface.
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{"snapshot-id": {"snap-123"}}, "id")
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "id",
+		},
+		Data:    []dataRow{},
+		Filters: map[string][]string{"snapshot-id": {"snap-123"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	want := []interface{}{
+		"ID", "Volume ID", "Volume Deleted", "Size (GiB)", "Start Time", "Age (days)",
+		"State", "Progress", "Encrypted", "KMS Key", "Description", "Tags",
+	}
+	if got := New("", "", nil, "").GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_getFilters tests the getFilters function.
+func TestResults_getFilters(t *testing.T) {
+	r := New("default", "us-east-1", map[string][]string{
+		"volume-id":         {"vol-123"},
+		"tag":               {"key=value"},
+		"encrypted":         {"false"},
+		FilterOlderThan:     {"90", "30"},
+		FilterVolumeDeleted: {"true"},
+	}, "id")
+	got, err := r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.OwnerIds, []string{"self"}) {
+		t.Errorf("OwnerIds = %v, want [self]", got.OwnerIds)
+	}
+	if r.olderThan != 30 {
+		t.Errorf("olderThan = %d, want 30", r.olderThan)
+	}
+	if !reflect.DeepEqual(r.volumeDeleted, []bool{true}) {
+		t.Errorf("volumeDeleted = %v, want [true]", r.volumeDeleted)
+	}
+	want := map[string][]string{
+		"volume-id": {"vol-123"},
+		"tag:key":   {"value"},
+		"encrypted": {"false"},
+	}
+	gotByName := make(map[string][]string, len(got.Filters))
+	for _, f := range got.Filters {
+		gotByName[*f.Name] = f.Values
+	}
+	if !reflect.DeepEqual(gotByName, want) {
+		t.Errorf("Results.getFilters()\n%#v\nwant\n%#v", gotByName, want)
+	}
+
+	r.Filters = map[string][]string{"snapshot-id": {"snap-123"}}
+	got, err = r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	if got.OwnerIds != nil || !reflect.DeepEqual(got.SnapshotIds, []string{"snap-123"}) {
+		t.Errorf("Results.getFilters() with ids: OwnerIds = %v, SnapshotIds = %v, want nil, [snap-123]",
+			got.OwnerIds, got.SnapshotIds)
+	}
+
+	for _, filters := range []map[string][]string{
+		{"tag": {"invalid"}},
+		{FilterOlderThan: {"ninety"}},
+		{FilterOlderThan: {"-1"}},
+		{FilterVolumeDeleted: {"maybe"}},
+	} {
+		r.Filters = filters
+		if _, err := r.getFilters(); err == nil {
+			t.Errorf("Results.getFilters(%v) expected error, got nil", filters)
+		}
+	}
+}
+
+// TestParseSnapshot tests the parseSnapshot function.
+func TestParseSnapshot(t *testing.T) {
+	startTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	encrypted := true
+	size := int32(100)
+	snapshot := types.Snapshot{
+		SnapshotId:  common.String("snap-123"),
+		VolumeId:    common.String("vol-123"),
+		VolumeSize:  &size,
+		StartTime:   &startTime,
+		State:       types.SnapshotStateCompleted,
+		Progress:    common.String("100%"),
+		Encrypted:   &encrypted,
+		KmsKeyId:    common.String("arn:aws:kms:us-east-1:123456789012:key/abc"),
+		Description: common.String("daily backup"),
+		Tags:        []types.Tag{{Key: common.String("Name"), Value: common.String("db")}},
+	}
+	want := dataRow{
+		SnapshotID:  "snap-123",
+		VolumeID:    "vol-123",
+		Size:        100,
+		StartTime:   "2026-01-01T12:00:00Z",
+		Age:         10,
+		State:       "completed",
+		Progress:    "100%",
+		Encrypted:   "true",
+		KmsKeyID:    "arn:aws:kms:us-east-1:123456789012:key/abc",
+		Description: "daily backup",
+		Tags:        map[string]string{"Name": "db"},
+	}
+	currentTime := startTime.Add(10*24*time.Hour + time.Hour)
+	if got := parseSnapshot(&snapshot, currentTime); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSnapshot()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_applyVolumeDeleted tests the applyVolumeDeleted function.
+func TestResults_applyVolumeDeleted(t *testing.T) {
+	existing := map[string]bool{"vol-1": true}
+	tests := []struct {
+		name          string
+		volumeDeleted []bool
+		want          []dataRow
+	}{
+		{
+			name: "no filter",
+			want: []dataRow{
+				{SnapshotID: "snap-1", VolumeID: "vol-1", VolumeDeleted: "false"},
+				{SnapshotID: "snap-2", VolumeID: "vol-2", VolumeDeleted: "true"},
+			},
+		},
+		{
+			name:          "deleted only",
+			volumeDeleted: []bool{true},
+			want:          []dataRow{{SnapshotID: "snap-2", VolumeID: "vol-2", VolumeDeleted: "true"}},
+		},
+		{
+			name:          "existing only",
+			volumeDeleted: []bool{false},
+			want:          []dataRow{{SnapshotID: "snap-1", VolumeID: "vol-1", VolumeDeleted: "false"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Results{
+				Data:          []dataRow{{SnapshotID: "snap-1", VolumeID: "vol-1"}, {SnapshotID: "snap-2", VolumeID: "vol-2"}},
+				volumeDeleted: tt.volumeDeleted,
+			}
+			r.applyVolumeDeleted(existing)
+			if !reflect.DeepEqual(r.Data, tt.want) {
+				t.Errorf("Results.applyVolumeDeleted()\n%#v\nwant\n%#v", r.Data, tt.want)
+			}
+		})
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	r := &Results{Data: []dataRow{{SnapshotID: "snap-1", Age: 200}, {SnapshotID: "snap-2", Age: 30}}}
+	if err := r.sortResults("age"); err != nil {
+		t.Fatalf("sortResults(age) unexpected error: %v", err)
+	}
+	if r.Data[0].SnapshotID != "snap-2" {
+		t.Errorf("sortResults(age) first row = %s, want snap-2", r.Data[0].SnapshotID)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	want := map[string]string{
+		"id":             "SnapshotID",
+		"volume-id":      "VolumeID",
+		"volume-deleted": "VolumeDeleted",
+		"size":           "Size",
+		"start-time":     "StartTime",
+		"age":            "Age",
+		"state":          "State",
+		"progress":       "Progress",
+		"encrypted":      "Encrypted",
+	}
+	got, err := GetSortFields("id")
+	if err != nil {
+		t.Fatalf("GetSortFields() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortFields()\n%#v\nwant\n%#v", got, want)
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnet
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the subnet command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids               []string `filter:"subnet-id"`
+	Names             []string `filter:"tag:Name"`
+	Tags              []string `filter:"tag"`
+	TagsKey           []string `filter:"tag-key"`
+	VpcIDs            []string `filter:"vpc-id"`
+	AvailabilityZones []string `filter:"availability-zone"`
+	Cidrs             []string `filter:"cidr-block"`
+	DefaultForAz      []string `filter:"default-for-az"`
+}
+
+// cmdF holds the values of the subnet command filter flags.
+var cmdF = cmdFilters{}
+
+// The subnets search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "subnet",
+	New:           search.WithoutInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"subnet-"},
+	IDFilter:      "subnet-id",
+	Command: &search.Command{
+		Short: "Search for subnets.",
+		Long: `
+Search for subnets.
+You can search subnets using the following filters:
+  ids, names, tags, tags-key, vpc-ids, availability-zones, cidrs and default.
+You can use multiple values for each filter, separated by comma.
+Example: --ids subnet-1230456078901,subnet-1230456078902
+
+Each subnet shows the available IPv4 addresses and the percentage in use.
+AWS reserves five addresses in every subnet, they are not counted as usable.
+
+You can use multiple filters at same time, for example:
+	awss subnet -v vpc-1230456078901 -z a,b --sort utilization
+
+Use --all to search for all subnets without any filter. This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "subnets",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter subnets by ids. `subnet-1230456078901,subnet-1230456078902`",
+			},
+			{
+				Name: "names", Shorthand: "n", Value: &cmdF.Names,
+				Usage: "Filter subnets by names. It searches using the 'tag:Name'. `private-a,private-b`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter subnets by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter subnets by tags key. `Key,Environment`",
+			},
+			{
+				Name: "vpc-ids", Shorthand: "v", Value: &cmdF.VpcIDs,
+				Usage: "Filter subnets by VPC IDs. `vpc-1230456078901,vpc-1230456078902`",
+			},
+			{
+				Name: "availability-zones", Shorthand: "z", Value: &cmdF.AvailabilityZones,
+				Usage: "Filter subnets by availability zones. It will append to current region. `a,b`",
+			},
+			{
+				Name: "cidrs", Shorthand: "c", Value: &cmdF.Cidrs,
+				Usage: "Filter subnets by IPv4 CIDR block. `10.0.1.0/24`",
+			},
+			{
+				Name: "default", Shorthand: "d", Value: &cmdF.DefaultForAz,
+				Usage: "Filter subnets by default for AZ flag. `true,false`",
+			},
+		},
+		DefaultSort: "id",
+	},
+})
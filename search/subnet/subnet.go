@@ -0,0 +1,302 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package subnet contains the search for subnets.
+//
+// It implements the common.Results interface.
+package subnet
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// reservedIPs is the number of IP addresses AWS reserves in every subnet.
+	reservedIPs = 5
+
+	// percent is used to convert a ratio into a percentage.
+	percent = 100
+)
+
+// Results describes results of the subnets search.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the subnets found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search.
+	Filters map[string][]string `json:"-"`
+}
+
+// dataRow represents a row of the subnets search results.
+type dataRow struct {
+	// SubnetID is the ID of the subnet.
+	SubnetID string `json:"id,omitempty" header:"ID" sort:"id"`
+
+	// SubnetName is the tag:Name of the subnet.
+	SubnetName string `json:"name,omitempty" header:"Name" sort:"name"`
+
+	// VpcID is the ID of the VPC the subnet belongs to.
+	VpcID string `json:"vpc_id,omitempty" header:"VPC ID" sort:"vpc-id"`
+
+	// AvailabilityZone is the AZ of the subnet.
+	AvailabilityZone string `json:"az,omitempty" header:"AZ" sort:"az"`
+
+	// CidrBlock is the IPv4 CIDR block of the subnet.
+	CidrBlock string `json:"cidr,omitempty" header:"IPv4 CIDR" sort:"cidr"`
+
+	// Ipv6CidrBlocks are the IPv6 CIDR blocks associated with the subnet.
+	Ipv6CidrBlocks []string `json:"ipv6_cidrs,omitempty" header:"IPv6 CIDRs"`
+
+	// DefaultForAz indicates whether this is the default subnet for the AZ.
+	DefaultForAz string `json:"default,omitempty" header:"Default" sort:"default"`
+
+	// State is the state of the subnet.
+	State string `json:"state,omitempty" header:"State" sort:"state"`
+
+	// AvailableIPs is the number of unused private IPv4 addresses in the subnet.
+	AvailableIPs int32 `json:"available_ips" header:"Available IPs" sort:"available-ips"`
+
+	// Utilization is the percentage of the usable IPv4 addresses in use.
+	Utilization float64 `json:"used_percent" header:"Used %" sort:"utilization"`
+
+	// Tags are the tags assigned to the subnet.
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// New initiates and returns a new instance of subnets results.
+func New(profile, region string, filters map[string][]string, sortField string) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:    []dataRow{},
+		Filters: filters,
+	}
+}
+
+// Search performs the subnets search.
+//
+// Results are stored in the Data field.
+func (r *Results) Search(ctx context.Context) {
+	input, err := r.getFilters()
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+
+	paginator := ec2.NewDescribeSubnetsPaginator(ec2.NewFromConfig(cfg), input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.AddError(fmt.Errorf("error describing subnets: %w", err))
+			return
+		}
+		for _, subnet := range page.Subnets { //nolint:gocritic
+			r.Data = append(r.Data, parseSubnet(&subnet))
+		}
+	}
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// parseSubnet converts a single Subnet into a dataRow.
+func parseSubnet(subnet *types.Subnet) dataRow {
+	row := dataRow{
+		SubnetID:         common.StringValue(subnet.SubnetId),
+		SubnetName:       common.TagName(subnet.Tags),
+		VpcID:            common.StringValue(subnet.VpcId),
+		AvailabilityZone: common.StringValue(subnet.AvailabilityZone),
+		CidrBlock:        common.StringValue(subnet.CidrBlock),
+		State:            string(subnet.State),
+		Tags:             common.TagsToMap(subnet.Tags),
+	}
+	for _, assoc := range subnet.Ipv6CidrBlockAssociationSet {
+		row.Ipv6CidrBlocks = append(row.Ipv6CidrBlocks, common.StringValue(assoc.Ipv6CidrBlock))
+	}
+	if subnet.DefaultForAz != nil {
+		row.DefaultForAz = strconv.FormatBool(*subnet.DefaultForAz)
+	}
+	if subnet.AvailableIpAddressCount != nil {
+		row.AvailableIPs = *subnet.AvailableIpAddressCount
+	}
+	row.Utilization = utilization(row.CidrBlock, row.AvailableIPs)
+	return row
+}
+
+// utilization returns the percentage of usable IPv4 addresses in use, rounded to two decimals.
+//
+// It returns 0 when the CIDR block is invalid or has no usable addresses, see UsableIPs.
+func utilization(cidr string, available int32) float64 {
+	return Utilization(UsableIPs(cidr), available)
+}
+
+// UsableIPs returns the number of usable IPv4 addresses of a subnet CIDR block.
+//
+// AWS reserves five addresses in every subnet, so they are not counted as usable.
+// It returns 0 when the CIDR block is invalid or has no usable addresses.
+func UsableIPs(cidr string) float64 {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0
+	}
+	ones, bits := ipNet.Mask.Size()
+	return math.Max(math.Pow(2, float64(bits-ones))-reservedIPs, 0)
+}
+
+// Utilization returns the percentage of the usable IPv4 addresses in use, rounded to two decimals.
+//
+// It returns 0 when there are no usable addresses.
+func Utilization(usable float64, available int32) float64 {
+	if usable <= 0 {
+		return 0
+	}
+	used := (usable - float64(available)) / usable * percent
+	return math.Round(used*percent) / percent
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getFilters returns the filters used to search.
+//
+// The filters are defined in the results.Filters field.
+// Except for "subnet-id", "tag:Name", "tag" and "availability-zone", all other filters are passed as-is.
+func (r *Results) getFilters() (*ec2.DescribeSubnetsInput, error) {
+	input := ec2.DescribeSubnetsInput{}
+
+	for key, values := range r.Filters {
+		switch key {
+		case "subnet-id":
+			input.SubnetIds = values
+		case "tag:Name":
+			input.Filters = append(input.Filters, common.FilterNames(values)...)
+		case "tag":
+			tagFilters, err := common.FilterTags(values)
+			if err != nil {
+				return nil, fmt.Errorf("building tag filters: %w", err)
+			}
+			input.Filters = append(input.Filters, tagFilters...)
+		case "availability-zone":
+			input.Filters = append(input.Filters, common.FilterAvailabilityZones(values, r.Region)...)
+		default:
+			input.Filters = append(input.Filters, common.FilterDefault(key, values)...)
+		}
+	}
+	return &input, nil
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	fieldName := sortFields[field]
+	sort.Slice(r.Data, func(p, q int) bool {
+		pField := reflect.ValueOf(r.Data[p]).FieldByName(fieldName)
+		qField := reflect.ValueOf(r.Data[q]).FieldByName(fieldName)
+		switch pField.Kind() {
+		case reflect.Int32:
+			return pField.Int() < qField.Int()
+		case reflect.Float64:
+			return pField.Float() < qField.Float()
+		default:
+			return pField.String() < qField.String()
+		}
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package subnet contains the search for subnets.
+//
+// It implements the common.Results interface.
+package subnet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{"subnet-id": {"subnet-123"}}, "id")
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "id",
+		},
+		Data:    []dataRow{},
+		Filters: map[string][]string{"subnet-id": {"subnet-123"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	want := []interface{}{
+		"ID", "Name", "VPC ID", "AZ", "IPv4 CIDR", "IPv6 CIDRs",
+		"Default", "State", "Available IPs", "Used %", "Tags",
+	}
+	if got := New("", "", nil, "").GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_getFilters tests the getFilters function.
+func TestResults_getFilters(t *testing.T) {
+	r := New("default", "us-east-1", map[string][]string{
+		"subnet-id":         {"subnet-123"},
+		"tag:Name":          {"private-*"},
+		"tag":               {"key=value"},
+		"availability-zone": {"a"},
+		"vpc-id":            {"vpc-123"},
+	}, "id")
+	got, err := r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.SubnetIds, []string{"subnet-123"}) {
+		t.Errorf("SubnetIds = %v, want [subnet-123]", got.SubnetIds)
+	}
+	want := map[string][]string{
+		"tag:Name":          {"private-*"},
+		"tag:key":           {"value"},
+		"availability-zone": {"us-east-1a"},
+		"vpc-id":            {"vpc-123"},
+	}
+	gotByName := make(map[string][]string, len(got.Filters))
+	for _, f := range got.Filters {
+		gotByName[*f.Name] = f.Values
+	}
+	if !reflect.DeepEqual(gotByName, want) {
+		t.Errorf("Results.getFilters()\n%#v\nwant\n%#v", gotByName, want)
+	}
+
+	r.Filters = map[string][]string{"tag": {"invalid"}}
+	if _, err := r.getFilters(); err == nil {
+		t.Error("Results.getFilters() expected error for malformed tag, got nil")
+	}
+}
+
+// TestParseSubnet tests the parseSubnet function.
+func TestParseSubnet(t *testing.T) {
+	available, isDefault := int32(11), false
+	subnet := types.Subnet{
+		SubnetId:                common.String("subnet-123"),
+		VpcId:                   common.String("vpc-123"),
+		AvailabilityZone:        common.String("us-east-1a"),
+		CidrBlock:               common.String("10.0.0.0/24"),
+		DefaultForAz:            &isDefault,
+		State:                   types.SubnetStateAvailable,
+		AvailableIpAddressCount: &available,
+		Ipv6CidrBlockAssociationSet: []types.SubnetIpv6CidrBlockAssociation{
+			{Ipv6CidrBlock: common.String("2600:1f18::/64")},
+		},
+		Tags: []types.Tag{{Key: common.String("Name"), Value: common.String("private-a")}},
+	}
+	want := dataRow{
+		SubnetID:         "subnet-123",
+		SubnetName:       "private-a",
+		VpcID:            "vpc-123",
+		AvailabilityZone: "us-east-1a",
+		CidrBlock:        "10.0.0.0/24",
+		Ipv6CidrBlocks:   []string{"2600:1f18::/64"},
+		DefaultForAz:     "false",
+		State:            "available",
+		AvailableIPs:     11,
+		Utilization:      95.62,
+		Tags:             map[string]string{"Name": "private-a"},
+	}
+	if got := parseSubnet(&subnet); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSubnet()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestUtilization tests the utilization function.
+func TestUtilization(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		available int32
+		want      float64
+	}{
+		{name: "empty /24", cidr: "10.0.0.0/24", available: 251, want: 0},
+		{name: "full /28", cidr: "10.0.0.0/28", available: 0, want: 100},
+		{name: "half /27", cidr: "10.0.0.0/27", available: 13, want: 51.85},
+		{name: "invalid cidr", cidr: "invalid", available: 10, want: 0},
+		{name: "no usable addresses", cidr: "10.0.0.0/30", available: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utilization(tt.cidr, tt.available); got != tt.want {
+				t.Errorf("utilization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     string
+		wantErr   bool
+		wantFirst string
+	}{
+		{name: "sort by id", field: "id", wantFirst: "subnet-a"},
+		{name: "sort by available ips (numeric)", field: "available-ips", wantFirst: "subnet-c"},
+		{name: "sort by utilization (numeric)", field: "utilization", wantFirst: "subnet-b"},
+		{name: "invalid field", field: "invalid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Results{
+				Data: []dataRow{
+					{SubnetID: "subnet-b", AvailableIPs: 100, Utilization: 9.5},
+					{SubnetID: "subnet-c", AvailableIPs: 20, Utilization: 80},
+					{SubnetID: "subnet-a", AvailableIPs: 1000, Utilization: 10},
+				},
+			}
+			err := r.sortResults(tt.field)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sortResults(%q) error = %v, wantErr %v", tt.field, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && r.Data[0].SubnetID != tt.wantFirst {
+				t.Errorf("sortResults(%q) first row = %s, want %s", tt.field, r.Data[0].SubnetID, tt.wantFirst)
+			}
+		})
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	for _, f := range []string{"id", "name", "vpc-id", "az", "cidr", "default", "state", "available-ips", "utilization"} {
+		if _, err := GetSortFields(f); err != nil {
+			t.Errorf("GetSortFields(%q) unexpected error: %v", f, err)
+		}
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}
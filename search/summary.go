@@ -0,0 +1,72 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package search provides the entry point for the search command.
+//
+// It implements a search command that searches for resources in AWS.
+// The searches are done in parallel and the results are printed in the
+// specified format.
+package search
+
+import (
+	"fmt"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// Summary sums up the results of the searches of a run.
+type Summary struct {
+	// Searches is the number of searches, one per type, profile and region.
+	Searches int
+
+	// Failed is the number of searches with errors.
+	Failed int
+
+	// Rows is the number of rows found.
+	Rows int
+
+	// Errors are the errors of the searches, prefixed by their type, profile and region.
+	Errors []string
+}
+
+// AllFailed returns true if there were searches and all of them have errors.
+func (s Summary) AllFailed() bool {
+	return s.Searches > 0 && s.Failed == s.Searches
+}
+
+// summarize returns the summary of the given results.
+func summarize(results []common.Results) Summary {
+	summary := Summary{Searches: len(results), Errors: []string{}}
+
+	for _, r := range results {
+		summary.Rows += r.Len()
+
+		errors := r.GetErrors()
+		if len(errors) == 0 {
+			continue
+		}
+		summary.Failed++
+
+		prefix := fmt.Sprintf("[%s] [%s]", r.GetProfile(), r.GetRegion())
+		if t := r.GetType(); t != "" {
+			prefix = fmt.Sprintf("[%s] %s", t, prefix)
+		}
+		for _, e := range errors {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s %s", prefix, e.Message))
+		}
+	}
+	return summary
+}
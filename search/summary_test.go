@@ -0,0 +1,91 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+)
+
+// rowsResults is a mockResults with the given number of rows.
+type rowsResults struct {
+	mockResults
+	rows int
+}
+
+func (r *rowsResults) Len() int { return r.rows }
+
+// TestSummarize tests the summarize function and Summary.AllFailed.
+func TestSummarize(t *testing.T) {
+	newResults := func(resourceType, region string, rows int, errs ...string) common.Results {
+		r := &rowsResults{
+			mockResults: mockResults{BaseResults: common.BaseResults{
+				Type: resourceType, Profile: "p", Region: region,
+			}},
+			rows: rows,
+		}
+		for _, e := range errs {
+			r.AddError(errors.New(e))
+		}
+		return r
+	}
+
+	tests := []struct {
+		name          string
+		results       []common.Results
+		want          Summary
+		wantAllFailed bool
+	}{
+		{
+			name:    "no searches",
+			results: []common.Results{},
+			want:    Summary{Errors: []string{}},
+		},
+		{
+			name:    "rows without errors",
+			results: []common.Results{newResults("", "r1", 2), newResults("", "r2", 1)},
+			want:    Summary{Searches: 2, Rows: 3, Errors: []string{}},
+		},
+		{
+			name:    "partial failure",
+			results: []common.Results{newResults("", "r1", 2), newResults("", "r2", 0, "denied")},
+			want:    Summary{Searches: 2, Failed: 1, Rows: 2, Errors: []string{"[p] [r2] denied"}},
+		},
+		{
+			name:    "total failure with types",
+			results: []common.Results{newResults("ec2", "r1", 0, "denied", "throttled")},
+			want: Summary{
+				Searches: 1, Failed: 1, Errors: []string{"[ec2] [p] [r1] denied", "[ec2] [p] [r1] throttled"},
+			},
+			wantAllFailed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarize(tt.results)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summarize()\n%#v\nwant\n%#v", got, tt.want)
+			}
+			if got.AllFailed() != tt.wantAllFailed {
+				t.Errorf("Summary.AllFailed()\n%#v\nwant\n%#v", got.AllFailed(), tt.wantAllFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagged
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the tagged command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Tags          []string `filter:"tag"`
+	TagsKey       []string `filter:"tag-key"`
+	ResourceTypes []string `filter:"resource-type"`
+}
+
+// cmdF holds the values of the tagged command filter flags.
+var cmdF = cmdFilters{}
+
+// The tagged resources search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "tagged",
+	New:           search.WithoutInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	Command: &search.Command{
+		Short: "Search for resources of any service by tags.",
+		Long: `
+Search for resources of any service by tags, e.g. S3 buckets, RDS databases, Lambda functions or load balancers.
+It uses the Resource Groups Tagging API, so only the resources which have or had tags are found.
+You can search tagged resources using the following filters: tags, tags-key and resource-types.
+You can use multiple values for each filter, separated by comma.
+Example: --tags 'Project=foo:bar,Environment=*'
+
+The resource types are the service and, optionally, the resource type of the ARN.
+Example: --resource-types s3,rds:db,lambda:function
+
+Use --all to search for all tagged resources without any filter.
+This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' as a tag value to search for all values of a tag key)
+`,
+		Noun: "tagged resources",
+		Flags: []search.Flag{
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter tagged resources by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter tagged resources by tags key. `Key,Environment`",
+			},
+			{
+				Name: "resource-types", Shorthand: "r", Value: &cmdF.ResourceTypes,
+				Usage: "Filter tagged resources by service and resource type. `s3,rds:db,lambda:function`",
+			},
+		},
+		DefaultSort: "arn",
+	},
+})
@@ -0,0 +1,265 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tagged contains the search for tagged resources of any service.
+//
+// It uses the Resource Groups Tagging API and implements the common.Results interface.
+package tagged
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+const (
+	// arnParts is the number of parts of an ARN: arn:partition:service:region:account-id:resource.
+	arnParts = 6
+
+	// anyValue is the tag value matching any value of a tag key.
+	anyValue = "*"
+)
+
+// Results describes results of the tagged resources search.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the resources found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search.
+	Filters map[string][]string `json:"-"`
+}
+
+// dataRow represents a row of the tagged resources search results.
+type dataRow struct {
+	// ARN is the ARN of the resource.
+	ARN string `json:"arn,omitempty" header:"ARN" sort:"arn"`
+
+	// Service is the service of the resource, e.g. ec2, s3 or rds.
+	Service string `json:"service,omitempty" header:"Service" sort:"service"`
+
+	// ResourceType is the type of the resource within the service, e.g. instance, db or function.
+	ResourceType string `json:"type,omitempty" header:"Type" sort:"type"`
+
+	// ResourceID is the ID or name of the resource.
+	ResourceID string `json:"id,omitempty" header:"ID" sort:"id"`
+
+	// Tags are the tags assigned to the resource.
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// New initiates and returns a new instance of tagged resources results.
+func New(profile, region string, filters map[string][]string, sortField string) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:    []dataRow{},
+		Filters: filters,
+	}
+}
+
+// Search performs the tagged resources search.
+//
+// Results are stored in the Data field.
+func (r *Results) Search(ctx context.Context) {
+	input, err := r.getFilters()
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(resourcegroupstaggingapi.NewFromConfig(cfg), input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.AddError(fmt.Errorf("error getting resources: %w", err))
+			return
+		}
+		for _, mapping := range page.ResourceTagMappingList { //nolint:gocritic
+			r.Data = append(r.Data, parseResourceTagMapping(&mapping))
+		}
+	}
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// parseResourceTagMapping converts a single ResourceTagMapping into a dataRow.
+func parseResourceTagMapping(mapping *types.ResourceTagMapping) dataRow {
+	arn := common.StringValue(mapping.ResourceARN)
+	service, resourceType, resourceID := parseARN(arn)
+
+	tags := map[string]string{}
+	for _, tag := range mapping.Tags {
+		tags[common.StringValue(tag.Key)] = common.StringValue(tag.Value)
+	}
+
+	return dataRow{
+		ARN:          arn,
+		Service:      service,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Tags:         tags,
+	}
+}
+
+// parseARN returns the service, the resource type and the resource ID of an ARN.
+//
+// The resource part of an ARN is `type/id`, `type:id` or `id`. In the last case, the type is empty,
+// e.g. `arn:aws:s3:::my-bucket` or `arn:aws:sns:us-east-1:123456789012:my-topic`.
+func parseARN(arn string) (service, resourceType, resourceID string) {
+	parts := strings.SplitN(arn, ":", arnParts)
+	if len(parts) != arnParts {
+		return "", "", ""
+	}
+	service, resource := parts[2], parts[5]
+
+	if i := strings.IndexAny(resource, "/:"); i >= 0 {
+		return service, resource[:i], resource[i+1:]
+	}
+	return service, "", resource
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getFilters returns the filters used to search.
+//
+// The filters are defined in the results.Filters field.
+// The "tag" filter uses the common.ParseTags syntax. The value `*` matches any value of the key.
+// The "tag-key" filter matches the resources having the keys, whatever the value.
+// The "resource-type" filter takes the `service[:type]` values of the Resource Groups Tagging API, e.g. `ec2:instance`.
+func (r *Results) getFilters() (*resourcegroupstaggingapi.GetResourcesInput, error) {
+	input := resourcegroupstaggingapi.GetResourcesInput{}
+
+	for key, values := range r.Filters {
+		switch key {
+		case "tag":
+			tags, err := common.ParseTags(values)
+			if err != nil {
+				return nil, fmt.Errorf("building tag filters: %w", err)
+			}
+			for tagKey, tagValues := range tags {
+				if common.StringInSlice(anyValue, tagValues) {
+					tagValues = nil
+				}
+				input.TagFilters = append(input.TagFilters, types.TagFilter{Key: common.String(tagKey), Values: tagValues})
+			}
+		case "tag-key":
+			for _, tagKey := range values {
+				input.TagFilters = append(input.TagFilters, types.TagFilter{Key: common.String(tagKey)})
+			}
+		case "resource-type":
+			input.ResourceTypeFilters = values
+		default:
+			return nil, fmt.Errorf("invalid filter: %s", key)
+		}
+	}
+	sort.Slice(input.TagFilters, func(i, j int) bool {
+		return common.StringValue(input.TagFilters[i].Key) < common.StringValue(input.TagFilters[j].Key)
+	})
+	return &input, nil
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	fieldName := sortFields[field]
+	sort.Slice(r.Data, func(p, q int) bool {
+		return reflect.ValueOf(r.Data[p]).FieldByName(fieldName).String() <
+			reflect.ValueOf(r.Data[q]).FieldByName(fieldName).String()
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
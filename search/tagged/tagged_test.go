@@ -0,0 +1,167 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tagged contains the search for tagged resources of any service.
+//
+// It uses the Resource Groups Tagging API and implements the common.Results interface.
+package tagged
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{"tag": {"Project=foo"}}, "arn")
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "arn",
+		},
+		Data:    []dataRow{},
+		Filters: map[string][]string{"tag": {"Project=foo"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	want := []interface{}{"ARN", "Service", "Type", "ID", "Tags"}
+	if got := New("", "", nil, "").GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_getFilters tests the getFilters function.
+func TestResults_getFilters(t *testing.T) {
+	r := New("default", "us-east-1", map[string][]string{
+		"tag":           {"Project=foo:bar", "Environment=*"},
+		"tag-key":       {"Owner"},
+		"resource-type": {"s3", "rds:db"},
+	}, "arn")
+	got, err := r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	wantTagFilters := []types.TagFilter{
+		{Key: common.String("Environment")},
+		{Key: common.String("Owner")},
+		{Key: common.String("Project"), Values: []string{"foo", "bar"}},
+	}
+	if !reflect.DeepEqual(got.TagFilters, wantTagFilters) {
+		t.Errorf("Results.getFilters() TagFilters\n%#v\nwant\n%#v", got.TagFilters, wantTagFilters)
+	}
+	if !reflect.DeepEqual(got.ResourceTypeFilters, []string{"s3", "rds:db"}) {
+		t.Errorf("Results.getFilters() ResourceTypeFilters = %v, want [s3 rds:db]", got.ResourceTypeFilters)
+	}
+
+	for _, filters := range []map[string][]string{
+		{"tag": {"invalid"}},
+		{"instance-id": {"i-123"}},
+	} {
+		r.Filters = filters
+		if _, err := r.getFilters(); err == nil {
+			t.Errorf("Results.getFilters(%v) expected error, got nil", filters)
+		}
+	}
+}
+
+// TestParseResourceTagMapping tests the parseResourceTagMapping function.
+func TestParseResourceTagMapping(t *testing.T) {
+	mapping := types.ResourceTagMapping{
+		ResourceARN: common.String("arn:aws:rds:us-east-1:123456789012:db:my-db"),
+		Tags:        []types.Tag{{Key: common.String("Project"), Value: common.String("foo")}},
+	}
+	want := dataRow{
+		ARN:          "arn:aws:rds:us-east-1:123456789012:db:my-db",
+		Service:      "rds",
+		ResourceType: "db",
+		ResourceID:   "my-db",
+		Tags:         map[string]string{"Project": "foo"},
+	}
+	if got := parseResourceTagMapping(&mapping); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseResourceTagMapping()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestParseARN tests the parseARN function.
+func TestParseARN(t *testing.T) {
+	tests := []struct {
+		arn                                 string
+		wantService, wantType, wantResource string
+	}{
+		{"arn:aws:s3:::my-bucket", "s3", "", "my-bucket"},
+		{"arn:aws:ec2:us-east-1:123456789012:instance/i-123", "ec2", "instance", "i-123"},
+		{"arn:aws:lambda:us-east-1:123456789012:function:my-function", "lambda", "function", "my-function"},
+		{
+			"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188",
+			"elasticloadbalancing", "loadbalancer", "app/my-lb/50dc6c495c0c9188",
+		},
+		{"invalid", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arn, func(t *testing.T) {
+			service, resourceType, resourceID := parseARN(tt.arn)
+			if service != tt.wantService || resourceType != tt.wantType || resourceID != tt.wantResource {
+				t.Errorf("parseARN() = %q, %q, %q, want %q, %q, %q",
+					service, resourceType, resourceID, tt.wantService, tt.wantType, tt.wantResource)
+			}
+		})
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	r := &Results{Data: []dataRow{{ARN: "arn:2", Service: "s3"}, {ARN: "arn:1", Service: "rds"}}}
+	if err := r.sortResults("service"); err != nil {
+		t.Fatalf("sortResults(service) unexpected error: %v", err)
+	}
+	if r.Data[0].ARN != "arn:1" {
+		t.Errorf("sortResults(service) first row = %s, want arn:1", r.Data[0].ARN)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	want := map[string]string{
+		"arn":     "ARN",
+		"service": "Service",
+		"type":    "ResourceType",
+		"id":      "ResourceID",
+	}
+	got, err := GetSortFields("arn")
+	if err != nil {
+		t.Fatalf("GetSortFields() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortFields()\n%#v\nwant\n%#v", got, want)
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpc
+
+import (
+	"github.com/dyegoe/awss/search"
+)
+
+// cmdFilters represents the filters for the vpc command.
+//
+// The filters are used to filter the results.
+// common.StructToFilters is used to convert the struct to a map[string][]string.
+// The AWS filter names must be present in the struct tag `filter:"filter-name"`.
+type cmdFilters struct {
+	Ids       []string `filter:"vpc-id"`
+	Names     []string `filter:"tag:Name"`
+	Tags      []string `filter:"tag"`
+	TagsKey   []string `filter:"tag-key"`
+	Cidrs     []string `filter:"cidr-block-association.cidr-block"`
+	IsDefault []string `filter:"is-default"`
+}
+
+// cmdF holds the values of the vpc command filter flags.
+var cmdF = cmdFilters{}
+
+// The VPCs search and its command are registered when the package is imported.
+var _ = search.Register(search.Resource{
+	Name:          "vpc",
+	New:           search.WithoutInstanceName(New),
+	GetSortFields: GetSortFields,
+	Filters:       &cmdF,
+	IDPrefixes:    []string{"vpc-"},
+	IDFilter:      "vpc-id",
+	Command: &search.Command{
+		Short: "Search for VPCs.",
+		Long: `
+Search for VPCs.
+You can search VPCs using the following filters: ids, names, tags, tags-key, cidrs and default.
+You can use multiple values for each filter, separated by comma. Example: --ids vpc-1230456078901,vpc-1230456078902
+
+You can use multiple filters at same time, for example:
+	awss vpc -n 'prod-*' -d false
+
+The available IPs and the utilization of a VPC are summed from its subnets.
+
+Use --all to search for all VPCs without any filter. This flag cannot be combined with other filters.
+
+(You can use the wildcard '*' to search for all values in a filter)
+`,
+		Noun: "VPCs",
+		Flags: []search.Flag{
+			{
+				Name: "ids", Shorthand: "i", Value: &cmdF.Ids,
+				Usage: "Filter VPCs by ids. `vpc-1230456078901,vpc-1230456078902`",
+			},
+			{
+				Name: "names", Shorthand: "n", Value: &cmdF.Names,
+				Usage: "Filter VPCs by names. It searches using the 'tag:Name'. `vpc-1,vpc-2`",
+			},
+			{
+				Name: "tags", Shorthand: "t", Value: &cmdF.Tags,
+				Usage: "Filter VPCs by tags. `'Key=Value1:Value2,Environment=Production'`",
+			},
+			{
+				Name: "tags-key", Shorthand: "k", Value: &cmdF.TagsKey,
+				Usage: "Filter VPCs by tags key. `Key,Environment`",
+			},
+			{
+				Name: "cidrs", Shorthand: "c", Value: &cmdF.Cidrs,
+				Usage: "Filter VPCs by associated IPv4 CIDR blocks. `10.0.0.0/16`",
+			},
+			{
+				Name: "default", Shorthand: "d", Value: &cmdF.IsDefault,
+				Usage: "Filter VPCs by default flag. `true,false`",
+			},
+		},
+		DefaultSort: "name",
+	},
+})
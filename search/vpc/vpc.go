@@ -0,0 +1,314 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vpc contains the search for VPCs.
+//
+// It implements the common.Results interface.
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/dyegoe/awss/common"
+	"github.com/dyegoe/awss/search/subnet"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Results describes results of the VPCs search.
+type Results struct {
+	common.BaseResults
+
+	// Data contains the VPCs found.
+	Data []dataRow `json:"data"`
+
+	// Filters is a map of strings used to search.
+	Filters map[string][]string `json:"-"`
+}
+
+// dataRow represents a row of the VPCs search results.
+type dataRow struct {
+	// VpcID is the ID of the VPC.
+	VpcID string `json:"id,omitempty" header:"ID" sort:"id"`
+
+	// VpcName is the tag:Name of the VPC.
+	VpcName string `json:"name,omitempty" header:"Name" sort:"name"`
+
+	// State is the state of the VPC.
+	State string `json:"state,omitempty" header:"State" sort:"state"`
+
+	// IsDefault indicates whether the VPC is the default VPC.
+	IsDefault string `json:"default,omitempty" header:"Default" sort:"default"`
+
+	// CidrBlocks are the IPv4 CIDR blocks associated with the VPC.
+	CidrBlocks []string `json:"cidrs,omitempty" header:"IPv4 CIDRs"`
+
+	// Ipv6CidrBlocks are the IPv6 CIDR blocks associated with the VPC.
+	Ipv6CidrBlocks []string `json:"ipv6_cidrs,omitempty" header:"IPv6 CIDRs"`
+
+	// AvailableIPs is the number of unused private IPv4 addresses in the subnets of the VPC.
+	AvailableIPs int32 `json:"available_ips" header:"Available IPs" sort:"available-ips"`
+
+	// Utilization is the percentage of the usable IPv4 addresses of the subnets of the VPC in use.
+	Utilization float64 `json:"used_percent" header:"Used %" sort:"utilization"`
+
+	// Tags are the tags assigned to the VPC.
+	Tags map[string]string `json:"tags,omitempty" header:"Tags"`
+}
+
+// New initiates and returns a new instance of VPCs results.
+func New(profile, region string, filters map[string][]string, sortField string) *Results {
+	return &Results{
+		BaseResults: common.BaseResults{
+			Profile:   profile,
+			Region:    region,
+			Errors:    []common.SearchError{},
+			SortField: sortField,
+		},
+		Data:    []dataRow{},
+		Filters: filters,
+	}
+}
+
+// Search performs the VPCs search.
+//
+// Results are stored in the Data field.
+func (r *Results) Search(ctx context.Context) {
+	input, err := r.getFilters()
+	if err != nil {
+		r.AddError(common.InvalidFilterError(fmt.Errorf("error building filters: %w", err)))
+		return
+	}
+
+	cfg, err := common.AwsConfig(ctx, r.Profile, r.Region)
+	if err != nil {
+		r.AddError(fmt.Errorf("error getting aws config: %w", err))
+		return
+	}
+
+	client := ec2.NewFromConfig(cfg)
+	paginator := ec2.NewDescribeVpcsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.AddError(fmt.Errorf("error describing vpcs: %w", err))
+			return
+		}
+		for _, vpc := range page.Vpcs { //nolint:gocritic
+			r.Data = append(r.Data, parseVpc(&vpc))
+		}
+	}
+
+	if err := r.enrichUtilization(ctx, client); err != nil {
+		r.AddError(err)
+	}
+
+	if r.SortField == "" {
+		return
+	}
+	if err := r.sortResults(r.SortField); err != nil {
+		r.AddError(err)
+	}
+}
+
+// parseVpc converts a single Vpc into a dataRow.
+//
+// Only the CIDR blocks in the associated state are listed.
+func parseVpc(vpc *types.Vpc) dataRow {
+	row := dataRow{
+		VpcID:   common.StringValue(vpc.VpcId),
+		VpcName: common.TagName(vpc.Tags),
+		State:   string(vpc.State),
+		Tags:    common.TagsToMap(vpc.Tags),
+	}
+	if vpc.IsDefault != nil {
+		row.IsDefault = strconv.FormatBool(*vpc.IsDefault)
+	}
+	for _, assoc := range vpc.CidrBlockAssociationSet {
+		if assoc.CidrBlockState != nil && assoc.CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		row.CidrBlocks = append(row.CidrBlocks, common.StringValue(assoc.CidrBlock))
+	}
+	for _, assoc := range vpc.Ipv6CidrBlockAssociationSet {
+		if assoc.Ipv6CidrBlockState != nil && assoc.Ipv6CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		row.Ipv6CidrBlocks = append(row.Ipv6CidrBlocks, common.StringValue(assoc.Ipv6CidrBlock))
+	}
+	return row
+}
+
+// usage is the IPv4 address usage of the subnets of a VPC.
+type usage struct {
+	usable    float64
+	available int32
+}
+
+// enrichUtilization sets the available IPs and the utilization of each VPC from its subnets.
+//
+// The subnets are described by VPC ID, in chunks of common.MaxFilterValues IDs.
+// The usable addresses of each subnet exclude the ones reserved by AWS, see subnet.UsableIPs.
+func (r *Results) enrichUtilization(ctx context.Context, client *ec2.Client) error {
+	vpcIDs := make([]string, 0, len(r.Data))
+	for i := range r.Data {
+		vpcIDs = append(vpcIDs, r.Data[i].VpcID)
+	}
+
+	usages := map[string]usage{}
+	for _, chunk := range common.ChunkValues(vpcIDs, common.MaxFilterValues) {
+		paginator := ec2.NewDescribeSubnetsPaginator(client, &ec2.DescribeSubnetsInput{
+			Filters: []types.Filter{{Name: common.String("vpc-id"), Values: chunk}},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("error describing subnets: %w", err)
+			}
+			addSubnetUsages(usages, page.Subnets)
+		}
+	}
+
+	for i := range r.Data {
+		u := usages[r.Data[i].VpcID]
+		r.Data[i].AvailableIPs = u.available
+		r.Data[i].Utilization = subnet.Utilization(u.usable, u.available)
+	}
+	return nil
+}
+
+// addSubnetUsages adds the usable and available IPv4 addresses of the subnets to the usage of their VPC.
+func addSubnetUsages(usages map[string]usage, subnets []types.Subnet) {
+	for i := range subnets {
+		vpcID := common.StringValue(subnets[i].VpcId)
+		u := usages[vpcID]
+		u.usable += subnet.UsableIPs(common.StringValue(subnets[i].CidrBlock))
+		if subnets[i].AvailableIpAddressCount != nil {
+			u.available += *subnets[i].AvailableIpAddressCount
+		}
+		usages[vpcID] = u
+	}
+}
+
+// Len returns the length of the results.
+func (r *Results) Len() int { return len(r.Data) }
+
+// GetHeaders returns the tag `header` of the struct fields.
+func (r *Results) GetHeaders() []interface{} {
+	headers := []interface{}{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if header, ok := field.Tag.Lookup("header"); ok {
+			headers = append(headers, header)
+		}
+	}
+
+	return headers
+}
+
+// GetRows iterates results.Data and returns the results as a slice of interface{}.
+func (r *Results) GetRows() []interface{} {
+	rows := []interface{}{}
+
+	for _, row := range r.Data { //nolint:gocritic
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getFilters returns the filters used to search.
+//
+// The filters are defined in the results.Filters field.
+// Except for "vpc-id", "tag:Name" and "tag", all other filters are passed as-is.
+func (r *Results) getFilters() (*ec2.DescribeVpcsInput, error) {
+	input := ec2.DescribeVpcsInput{}
+
+	for key, values := range r.Filters {
+		switch key {
+		case "vpc-id":
+			input.VpcIds = values
+		case "tag:Name":
+			input.Filters = append(input.Filters, common.FilterNames(values)...)
+		case "tag":
+			tagFilters, err := common.FilterTags(values)
+			if err != nil {
+				return nil, fmt.Errorf("building tag filters: %w", err)
+			}
+			input.Filters = append(input.Filters, tagFilters...)
+		default:
+			input.Filters = append(input.Filters, common.FilterDefault(key, values)...)
+		}
+	}
+	return &input, nil
+}
+
+// sortResults sorts the results by the given field.
+func (r *Results) sortResults(field string) error {
+	sortFields, err := GetSortFields(field)
+	if err != nil {
+		return err
+	}
+
+	fieldName := sortFields[field]
+	sort.Slice(r.Data, func(p, q int) bool {
+		pField := reflect.ValueOf(r.Data[p]).FieldByName(fieldName)
+		qField := reflect.ValueOf(r.Data[q]).FieldByName(fieldName)
+		switch pField.Kind() {
+		case reflect.Int32:
+			return pField.Int() < qField.Int()
+		case reflect.Float64:
+			return pField.Float() < qField.Float()
+		default:
+			return pField.String() < qField.String()
+		}
+	})
+	return nil
+}
+
+// GetSortFields returns a map of the sort fields and their corresponding struct field.
+//
+// The sort fields are defined in the struct tag `sort` on dataRow.
+// The function returns an error if the given field is not a valid sort field.
+func GetSortFields(f string) (map[string]string, error) {
+	sortFields := map[string]string{}
+
+	v := reflect.ValueOf(dataRow{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if s, ok := field.Tag.Lookup("sort"); ok {
+			sortFields[s] = field.Name
+		}
+	}
+
+	if _, ok := sortFields[f]; !ok {
+		options := make([]string, 0, len(sortFields))
+		for k := range sortFields {
+			options = append(options, k)
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("invalid sort field: %s. The options are: %s", f, common.StringSliceToString(options, ", "))
+	}
+	return sortFields, nil
+}
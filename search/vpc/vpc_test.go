@@ -0,0 +1,190 @@
+/*
+Copyright © 2022 Dyego Alexandre Eugenio github@dyego.com.br
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vpc contains the search for VPCs.
+//
+// It implements the common.Results interface.
+package vpc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyegoe/awss/common"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// TestNew tests the New function.
+func TestNew(t *testing.T) {
+	got := New("default", "us-east-1", map[string][]string{"vpc-id": {"vpc-123"}}, "name")
+	want := &Results{
+		BaseResults: common.BaseResults{
+			Profile:   "default",
+			Region:    "us-east-1",
+			Errors:    []common.SearchError{},
+			SortField: "name",
+		},
+		Data:    []dataRow{},
+		Filters: map[string][]string{"vpc-id": {"vpc-123"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_GetHeaders tests the GetHeaders function.
+func TestResults_GetHeaders(t *testing.T) {
+	want := []interface{}{
+		"ID", "Name", "State", "Default", "IPv4 CIDRs", "IPv6 CIDRs", "Available IPs", "Used %", "Tags",
+	}
+	if got := New("", "", nil, "").GetHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Results.GetHeaders()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_getFilters tests the getFilters function.
+func TestResults_getFilters(t *testing.T) {
+	r := New("default", "us-east-1", map[string][]string{
+		"vpc-id":     {"vpc-123"},
+		"tag:Name":   {"prod"},
+		"tag":        {"key=value"},
+		"is-default": {"false"},
+	}, "name")
+	got, err := r.getFilters()
+	if err != nil {
+		t.Fatalf("Results.getFilters() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.VpcIds, []string{"vpc-123"}) {
+		t.Errorf("VpcIds = %v, want [vpc-123]", got.VpcIds)
+	}
+	want := map[string][]string{
+		"tag:Name":   {"prod"},
+		"tag:key":    {"value"},
+		"is-default": {"false"},
+	}
+	gotByName := make(map[string][]string, len(got.Filters))
+	for _, f := range got.Filters {
+		gotByName[*f.Name] = f.Values
+	}
+	if !reflect.DeepEqual(gotByName, want) {
+		t.Errorf("Results.getFilters()\n%#v\nwant\n%#v", gotByName, want)
+	}
+
+	r.Filters = map[string][]string{"tag": {"invalid"}}
+	if _, err := r.getFilters(); err == nil {
+		t.Error("Results.getFilters() expected error for malformed tag, got nil")
+	}
+}
+
+// TestParseVpc tests the parseVpc function.
+func TestParseVpc(t *testing.T) {
+	isDefault := true
+	vpc := types.Vpc{
+		VpcId:     common.String("vpc-123"),
+		State:     types.VpcStateAvailable,
+		IsDefault: &isDefault,
+		CidrBlockAssociationSet: []types.VpcCidrBlockAssociation{
+			{
+				CidrBlock:      common.String("172.31.0.0/16"),
+				CidrBlockState: &types.VpcCidrBlockState{State: types.VpcCidrBlockStateCodeAssociated},
+			},
+			{
+				CidrBlock:      common.String("100.64.0.0/16"),
+				CidrBlockState: &types.VpcCidrBlockState{State: types.VpcCidrBlockStateCodeDisassociated},
+			},
+		},
+		Ipv6CidrBlockAssociationSet: []types.VpcIpv6CidrBlockAssociation{
+			{
+				Ipv6CidrBlock:      common.String("2600:1f18::/56"),
+				Ipv6CidrBlockState: &types.VpcCidrBlockState{State: types.VpcCidrBlockStateCodeAssociated},
+			},
+		},
+		Tags: []types.Tag{{Key: common.String("Name"), Value: common.String("default")}},
+	}
+	want := dataRow{
+		VpcID:          "vpc-123",
+		VpcName:        "default",
+		State:          "available",
+		IsDefault:      "true",
+		CidrBlocks:     []string{"172.31.0.0/16"},
+		Ipv6CidrBlocks: []string{"2600:1f18::/56"},
+		Tags:           map[string]string{"Name": "default"},
+	}
+	if got := parseVpc(&vpc); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseVpc()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestResults_sortResults tests the sortResults function.
+func TestResults_sortResults(t *testing.T) {
+	r := &Results{Data: []dataRow{{VpcID: "vpc-2", VpcName: "a"}, {VpcID: "vpc-1", VpcName: "b"}}}
+	if err := r.sortResults("id"); err != nil {
+		t.Fatalf("sortResults(id) unexpected error: %v", err)
+	}
+	if r.Data[0].VpcID != "vpc-1" {
+		t.Errorf("sortResults(id) first row = %s, want vpc-1", r.Data[0].VpcID)
+	}
+	if err := r.sortResults("invalid"); err == nil {
+		t.Error("sortResults(invalid) expected error, got nil")
+	}
+
+	r = &Results{Data: []dataRow{{VpcID: "vpc-1", Utilization: 10.2}, {VpcID: "vpc-2", Utilization: 9.5}}}
+	if err := r.sortResults("utilization"); err != nil {
+		t.Fatalf("sortResults(utilization) unexpected error: %v", err)
+	}
+	if r.Data[0].VpcID != "vpc-2" {
+		t.Errorf("sortResults(utilization) first row = %s, want vpc-2", r.Data[0].VpcID)
+	}
+}
+
+// TestAddSubnetUsages tests the addSubnetUsages function.
+func TestAddSubnetUsages(t *testing.T) {
+	available := []int32{251, 0, 10}
+	subnets := []types.Subnet{
+		{VpcId: common.String("vpc-1"), CidrBlock: common.String("10.0.0.0/24"), AvailableIpAddressCount: &available[0]},
+		{VpcId: common.String("vpc-1"), CidrBlock: common.String("10.0.1.0/24"), AvailableIpAddressCount: &available[1]},
+		{VpcId: common.String("vpc-2"), CidrBlock: common.String("10.1.0.0/28"), AvailableIpAddressCount: &available[2]},
+	}
+	want := map[string]usage{
+		"vpc-1": {usable: 502, available: 251},
+		"vpc-2": {usable: 11, available: 10},
+	}
+
+	got := map[string]usage{}
+	addSubnetUsages(got, subnets)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("addSubnetUsages()\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// TestGetSortFields tests the GetSortFields function.
+func TestGetSortFields(t *testing.T) {
+	want := map[string]string{
+		"id": "VpcID", "name": "VpcName", "state": "State", "default": "IsDefault",
+		"available-ips": "AvailableIPs", "utilization": "Utilization",
+	}
+	got, err := GetSortFields("id")
+	if err != nil {
+		t.Fatalf("GetSortFields() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSortFields()\n%#v\nwant\n%#v", got, want)
+	}
+	if _, err := GetSortFields("invalid"); err == nil {
+		t.Error("GetSortFields(invalid) expected error, got nil")
+	}
+}